@@ -0,0 +1,58 @@
+package jwt
+
+import (
+	"time"
+)
+
+// Times constructs a field that carries a slice of times, each is serialized as a NumericDate.
+func Times(key string, val []time.Time) Claim {
+	return Array(key, times(val))
+}
+
+// Durations constructs a field that carries a slice of durations, each is serialized in the
+// same way as `Duration`.
+func Durations(key string, val []time.Duration) Claim {
+	return Array(key, durations(val))
+}
+
+// Errors constructs a field that carries a slice of errors, each is serialized as the output
+// of its Error method and nil errors are skipped.
+func Errors(key string, val []error) Claim {
+	return Array(key, errArray(val))
+}
+
+type times []time.Time
+
+func (ts times) MarshalClaimArray() ([]interface{}, error) {
+	o := make([]interface{}, len(ts))
+	for i, t := range ts {
+		o[i] = formatNumericDate(t)
+	}
+
+	return o, nil
+}
+
+type durations []time.Duration
+
+func (ds durations) MarshalClaimArray() ([]interface{}, error) {
+	o := make([]interface{}, len(ds))
+	for i, d := range ds {
+		o[i] = d.String()
+	}
+
+	return o, nil
+}
+
+type errArray []error
+
+func (errs errArray) MarshalClaimArray() ([]interface{}, error) {
+	o := make([]interface{}, 0, len(errs))
+
+	for _, err := range errs {
+		if err != nil {
+			o = append(o, err.Error())
+		}
+	}
+
+	return o, nil
+}
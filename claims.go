@@ -1,8 +1,12 @@
 package jwt
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -194,6 +198,136 @@ func Bool(key string, val bool) Claim {
 	return Claim{Key: key, Type: BoolType, Interface: val}
 }
 
+// Float64 constructs a field with the given key and value.
+func Float64(key string, val float64) Claim {
+	return Claim{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(val))}
+}
+
+// Float32 constructs a field with the given key and value.
+func Float32(key string, val float32) Claim {
+	return Claim{Key: key, Type: Float32Type, Integer: int64(math.Float32bits(val))}
+}
+
+// Int32 constructs a field with the given key and value.
+func Int32(key string, val int32) Claim {
+	return Claim{Key: key, Type: Int32Type, Integer: int64(val)}
+}
+
+// Int16 constructs a field with the given key and value.
+func Int16(key string, val int16) Claim {
+	return Claim{Key: key, Type: Int16Type, Integer: int64(val)}
+}
+
+// Int8 constructs a field with the given key and value.
+func Int8(key string, val int8) Claim {
+	return Claim{Key: key, Type: Int8Type, Integer: int64(val)}
+}
+
+// Uint constructs a field with the given key and value.
+func Uint(key string, val uint) Claim {
+	return Uint64(key, uint64(val))
+}
+
+// Uint64 constructs a field with the given key and value.
+func Uint64(key string, val uint64) Claim {
+	return Claim{Key: key, Type: Uint64Type, Integer: int64(val)} //nolint:gosec // stored as bits.
+}
+
+// Uint32 constructs a field with the given key and value.
+func Uint32(key string, val uint32) Claim {
+	return Claim{Key: key, Type: Uint32Type, Integer: int64(val)}
+}
+
+// Uint16 constructs a field with the given key and value.
+func Uint16(key string, val uint16) Claim {
+	return Claim{Key: key, Type: Uint16Type, Integer: int64(val)}
+}
+
+// Uint8 constructs a field with the given key and value.
+func Uint8(key string, val uint8) Claim {
+	return Claim{Key: key, Type: Uint8Type, Integer: int64(val)}
+}
+
+// Uintptr constructs a field with the given key and value.
+func Uintptr(key string, val uintptr) Claim {
+	return Claim{Key: key, Type: UintptrType, Integer: int64(val)} //nolint:gosec // stored as bits.
+}
+
+// Complex128 constructs a field with the given key and value, it is serialized as a string
+// in the `strconv.FormatComplex` format as JSON has no complex numbers.
+func Complex128(key string, val complex128) Claim {
+	return Claim{Key: key, Type: Complex128Type, Interface: val}
+}
+
+// Complex64 constructs a field with the given key and value, it is serialized in the same
+// way as `Complex128`.
+func Complex64(key string, val complex64) Claim {
+	return Claim{Key: key, Type: Complex64Type, Interface: val}
+}
+
+// Duration constructs a field with the given key and value, it is serialized as a string
+// in the `time.ParseDuration` format.
+func Duration(key string, val time.Duration) Claim {
+	return Claim{Key: key, Type: DurationType, Integer: int64(val)}
+}
+
+// Binary constructs a field that carries an opaque binary blob, it is serialized as a
+// base64url string without padding.
+func Binary(key string, val []byte) Claim {
+	return Claim{Key: key, Type: BinaryType, Interface: val}
+}
+
+// ByteString constructs a field that carries UTF-8 encoded text as a []byte, it is
+// serialized as a string.
+func ByteString(key string, val []byte) Claim {
+	return Claim{Key: key, Type: ByteStringType, Interface: val}
+}
+
+// Object constructs a field with the given key and ObjectMarshaler, it is serialized as a
+// JSON object.
+func Object(key string, val ObjectMarshaler) Claim {
+	return Claim{Key: key, Type: ObjectMarshalerType, Interface: val}
+}
+
+// Array constructs a field with the given key and ArrayMarshaler, it is serialized as a
+// JSON array.
+func Array(key string, val ArrayMarshaler) Claim {
+	return Claim{Key: key, Type: ArrayMarshalerType, Interface: val}
+}
+
+// Stringer constructs a field with the given key and the output of the value's String
+// method, the String method is called when the token is signed.
+func Stringer(key string, val fmt.Stringer) Claim {
+	return Claim{Key: key, Type: StringerType, Interface: val}
+}
+
+// NamedError constructs a field with the given key and the output of the error's Error
+// method, a nil error is skipped.
+func NamedError(key string, err error) Claim {
+	if err == nil {
+		return Skip()
+	}
+
+	return Claim{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Error is shorthand for the common idiom NamedError("error", err).
+func Error(err error) Claim {
+	return NamedError("error", err)
+}
+
+// Namespace creates a named, isolated scope within the token claims, all subsequent
+// unregistered claims are added to the new namespace as a nested JSON object. Registered
+// claims are always added to the top level of the token claims.
+func Namespace(key string) Claim {
+	return Claim{Key: key, Type: NamespaceType}
+}
+
+// Skip constructs a no-op field.
+func Skip() Claim {
+	return Claim{Type: SkipType}
+}
+
 // Reflect constructs a field with the given key and an arbitrary object. It uses
 // an encoding-appropriate, reflection-based function to lazily serialize nearly
 // any object into the logging context, but it's relatively slow and
@@ -212,32 +346,66 @@ func Reflect(key string, val interface{}) Claim {
 // Since byte/uint8 and rune/int32 are aliases, Any can't differentiate between
 // them. To minimize surprises, []byte values are treated as binary blobs, byte
 // values are treated as uint8, and runes are always treated as integers.
+//
+//nolint:cyclop,funlen // a case per type.
 func Any(key string, value interface{}) Claim {
 	switch val := value.(type) {
+	case ObjectMarshaler:
+		return Object(key, val)
+	case ArrayMarshaler:
+		return Array(key, val)
 	case bool:
 		return Bool(key, val)
+	case complex128:
+		return Complex128(key, val)
+	case complex64:
+		return Complex64(key, val)
+	case float64:
+		return Float64(key, val)
+	case float32:
+		return Float32(key, val)
 	case int:
 		return Int(key, val)
 	case int64:
 		return Int64(key, val)
+	case int32:
+		return Int32(key, val)
+	case int16:
+		return Int16(key, val)
+	case int8:
+		return Int8(key, val)
 	case string:
 		return String(key, val)
 	case []string:
 		return Strings(key, val)
+	case uint:
+		return Uint(key, val)
+	case uint64:
+		return Uint64(key, val)
+	case uint32:
+		return Uint32(key, val)
+	case uint16:
+		return Uint16(key, val)
+	case uint8:
+		return Uint8(key, val)
+	case uintptr:
+		return Uintptr(key, val)
+	case []byte:
+		return Binary(key, val)
 	case time.Time:
 		return Time(key, val)
-	// case []time.Time:
-	// 	return Times(key, val)
-	// case time.Duration:
-	// 	return Duration(key, val)
-	// case []time.Duration:
-	// 	return Durations(key, val)
-	// case error:
-	// 	return NamedError(key, val)
-	// case []error:
-	// 	return Errors(key, val)
-	// case fmt.Stringer:
-	// 	return Stringer(key, val)
+	case []time.Time:
+		return Times(key, val)
+	case time.Duration:
+		return Duration(key, val)
+	case []time.Duration:
+		return Durations(key, val)
+	case error:
+		return NamedError(key, val)
+	case []error:
+		return Errors(key, val)
+	case fmt.Stringer:
+		return Stringer(key, val)
 	default:
 		return Reflect(key, val)
 	}
@@ -251,14 +419,21 @@ func ConstructClaimsFromSlice(claims ...Claim) (*pascaljwt.Claims, error) {
 		Set:        map[string]interface{}{},
 	}
 
+	set := tokenClaims.Set
+
 	for _, claim := range claims {
-		if claim.IsRegistered() {
+		switch {
+		case claim.Type == NamespaceType:
+			namespace := map[string]interface{}{}
+			set[claim.Key] = namespace
+			set = namespace
+		case claim.IsRegistered():
 			err := constructRegisteredClaim(tokenClaims, claim)
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			err := constructUnregisteredClaim(tokenClaims, claim)
+		default:
+			err := constructUnregisteredClaim(set, claim)
 			if err != nil {
 				return nil, err
 			}
@@ -296,6 +471,7 @@ func constructRegisteredClaim(tokenClaims *pascaljwt.Claims, claim Claim) error
 			}
 
 			tokenClaims.Registered.Expires = pascaljwt.NewNumericTime(t)
+			tokenClaims.Set[Expires] = formatNumericDate(t)
 		} else {
 			return fmt.Errorf("%w for exp", ErrInvalidTypeForClaim)
 		}
@@ -307,6 +483,7 @@ func constructRegisteredClaim(tokenClaims *pascaljwt.Claims, claim Claim) error
 			}
 
 			tokenClaims.Registered.NotBefore = pascaljwt.NewNumericTime(t)
+			tokenClaims.Set[NotBefore] = formatNumericDate(t)
 		} else {
 			return fmt.Errorf("%w for nbf", ErrInvalidTypeForClaim)
 		}
@@ -318,6 +495,7 @@ func constructRegisteredClaim(tokenClaims *pascaljwt.Claims, claim Claim) error
 			}
 
 			tokenClaims.Registered.Issued = pascaljwt.NewNumericTime(t)
+			tokenClaims.Set[Issued] = formatNumericDate(t)
 		} else {
 			return fmt.Errorf("%w for iat", ErrInvalidTypeForClaim)
 		}
@@ -334,26 +512,66 @@ var ErrUnsupportedClaimType = errors.New("unsupported claim type")
 // ErrClaimFormatInvalid is returned when a claim format is invalid.
 var ErrClaimFormatInvalid = errors.New("claim format is invalid")
 
-// constructRegisteredClaim adds unregistered `Claim` fields to the supplied `pascaljwt.Claims`.
-func constructUnregisteredClaim(tokenClaims *pascaljwt.Claims, claim Claim) error {
+// constructUnregisteredClaim adds unregistered `Claim` fields to the supplied claim set.
+//
+//nolint:cyclop,funlen,gocognit // a case per type.
+func constructUnregisteredClaim(set map[string]interface{}, claim Claim) error {
 	switch claim.Type {
-	case ArrayMarshalerType, BinaryType, ByteStringType, Complex128Type, Complex64Type, DurationType,
-		ErrorType, Float32Type, Float64Type, NamespaceType, ObjectMarshalerType, ReflectType, SkipType,
-		StringerType, Uint16Type, Uint32Type, Uint64Type, Uint8Type, UintptrType, UnknownType:
+	case NamespaceType, UnknownType:
 		return fmt.Errorf("%w: %d", ErrUnsupportedClaimType, claim.Type)
+	case SkipType:
 	case Int8Type, Int16Type, Int32Type, Int64Type:
-		tokenClaims.Set[claim.Key] = claim.Integer
+		set[claim.Key] = claim.Integer
+	case Uint8Type, Uint16Type, Uint32Type, Uint64Type, UintptrType:
+		set[claim.Key] = uint64(claim.Integer) //nolint:gosec // stored as bits.
+	case Float64Type:
+		f := math.Float64frombits(uint64(claim.Integer)) //nolint:gosec // stored as bits.
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("%w float claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		set[claim.Key] = f
+	case Float32Type:
+		f := math.Float32frombits(uint32(claim.Integer)) //nolint:gosec // stored as bits.
+		if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+			return fmt.Errorf("%w float claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		set[claim.Key] = f
+	case Complex128Type, Complex64Type:
+		switch v := claim.Interface.(type) {
+		case complex128:
+			set[claim.Key] = strconv.FormatComplex(v, 'g', -1, 128) //nolint:mnd // bit size.
+		case complex64:
+			set[claim.Key] = strconv.FormatComplex(complex128(v), 'g', -1, 64) //nolint:mnd // bit size.
+		default:
+			return fmt.Errorf("%w complex claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+	case DurationType:
+		set[claim.Key] = time.Duration(claim.Integer).String()
 	case StringType:
-		tokenClaims.Set[claim.Key] = claim.String
+		set[claim.Key] = claim.String
 	case StringsType:
 		if v, ok := claim.Interface.([]string); ok {
-			tokenClaims.Set[claim.Key] = v
+			set[claim.Key] = v
 		} else {
 			return fmt.Errorf("%w []string claim type: %s", ErrClaimFormatInvalid, claim.Key)
 		}
+	case BinaryType:
+		if v, ok := claim.Interface.([]byte); ok {
+			set[claim.Key] = base64.RawURLEncoding.EncodeToString(v)
+		} else {
+			return fmt.Errorf("%w binary claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+	case ByteStringType:
+		if v, ok := claim.Interface.([]byte); ok {
+			set[claim.Key] = string(v)
+		} else {
+			return fmt.Errorf("%w byte string claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
 	case BoolType:
 		if b, ok := claim.Interface.(bool); ok {
-			tokenClaims.Set[claim.Key] = b
+			set[claim.Key] = b
 		} else {
 			return fmt.Errorf("%w bool claim type: %s", ErrClaimFormatInvalid, claim.Key)
 		}
@@ -363,7 +581,51 @@ func constructUnregisteredClaim(tokenClaims *pascaljwt.Claims, claim Claim) erro
 			return fmt.Errorf("%w for %s", err, claim.Key)
 		}
 
-		tokenClaims.Set[claim.Key] = pascaljwt.NewNumericTime(t)
+		set[claim.Key] = formatNumericDate(t)
+	case ObjectMarshalerType:
+		v, ok := claim.Interface.(ObjectMarshaler)
+		if !ok || v == nil {
+			return fmt.Errorf("%w object claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		o, err := v.MarshalClaimObject()
+		if err != nil {
+			return fmt.Errorf("%w object claim type: %s: %w", ErrClaimFormatInvalid, claim.Key, err)
+		}
+
+		set[claim.Key] = o
+	case ArrayMarshalerType:
+		v, ok := claim.Interface.(ArrayMarshaler)
+		if !ok || v == nil {
+			return fmt.Errorf("%w array claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		o, err := v.MarshalClaimArray()
+		if err != nil {
+			return fmt.Errorf("%w array claim type: %s: %w", ErrClaimFormatInvalid, claim.Key, err)
+		}
+
+		set[claim.Key] = o
+	case StringerType:
+		v, ok := claim.Interface.(fmt.Stringer)
+		if !ok || v == nil {
+			return fmt.Errorf("%w stringer claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		set[claim.Key] = v.String()
+	case ErrorType:
+		v, ok := claim.Interface.(error)
+		if !ok || v == nil {
+			return fmt.Errorf("%w error claim type: %s", ErrClaimFormatInvalid, claim.Key)
+		}
+
+		set[claim.Key] = v.Error()
+	case ReflectType:
+		if _, err := json.Marshal(claim.Interface); err != nil {
+			return fmt.Errorf("%w reflect claim type: %s: %w", ErrClaimFormatInvalid, claim.Key, err)
+		}
+
+		set[claim.Key] = claim.Interface
 	default:
 		return fmt.Errorf("%w: %d", ErrUnsupportedClaimType, claim.Type)
 	}
@@ -0,0 +1,156 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+type testObject struct {
+	Name string
+}
+
+func (o testObject) MarshalClaimObject() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": o.Name}, nil
+}
+
+func TestAny_Serialization(t *testing.T) {
+	issued := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		expect string
+		ctype  jwt.ClaimType
+	}{
+		{"bool", true, `true`, jwt.BoolType},
+		{"int", 42, `42`, jwt.Int64Type},
+		{"int32", int32(-7), `-7`, jwt.Int32Type},
+		{"int16", int16(-300), `-300`, jwt.Int16Type},
+		{"int8", int8(-8), `-8`, jwt.Int8Type},
+		{"uint", uint(9), `9`, jwt.Uint64Type},
+		{"uint64", uint64(math.MaxUint64), `18446744073709551615`, jwt.Uint64Type},
+		{"uint32", uint32(math.MaxUint32), `4294967295`, jwt.Uint32Type},
+		{"uint16", uint16(65535), `65535`, jwt.Uint16Type},
+		{"uint8", uint8(255), `255`, jwt.Uint8Type},
+		{"uintptr", uintptr(12), `12`, jwt.UintptrType},
+		{"float64", 0.25, `0.25`, jwt.Float64Type},
+		{"float32", float32(0.1), `0.1`, jwt.Float32Type},
+		{"complex128", complex(1, 2), `"(1+2i)"`, jwt.Complex128Type},
+		{"complex64", complex64(complex(1.5, -1)), `"(1.5-1i)"`, jwt.Complex64Type},
+		{"string", "value", `"value"`, jwt.StringType},
+		{"strings", []string{"a", "b"}, `["a","b"]`, jwt.StringsType},
+		{"binary", []byte{0xfb, 0xff}, `"-_8"`, jwt.BinaryType},
+		{"time", issued, `1700000000`, jwt.TimeType},
+		{"times", []time.Time{issued}, `[1700000000]`, jwt.ArrayMarshalerType},
+		{"duration", 90 * time.Second, `"1m30s"`, jwt.DurationType},
+		{"durations", []time.Duration{time.Second, time.Hour}, `["1s","1h0m0s"]`, jwt.ArrayMarshalerType},
+		{"error", errors.New("failed"), `"failed"`, jwt.ErrorType},
+		{"errors", []error{errors.New("a"), nil, errors.New("b")}, `["a","b"]`, jwt.ArrayMarshalerType},
+		{"stringer", net.IPv4(192, 0, 2, 1), `"192.0.2.1"`, jwt.StringerType},
+		{"object", testObject{Name: "test"}, `{"name":"test"}`, jwt.ObjectMarshalerType},
+		{"map", map[string]interface{}{"nested": map[string]interface{}{"a": 1}}, `{"nested":{"a":1}}`, jwt.ReflectType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := jwt.Any("key", tt.value)
+			if claim.Type != tt.ctype {
+				t.Errorf("jwt.Any().Type: expected '%d', returned '%d'", tt.ctype, claim.Type)
+			}
+
+			claims, err := jwt.ConstructClaimsFromSlice(claim)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			out, err := json.Marshal(claims.Set["key"])
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			expectString(t, "json.Marshal()", string(out), tt.expect)
+		})
+	}
+}
+
+func TestConstructClaimsFromSlice_Namespace(t *testing.T) {
+	claims, err := jwt.ConstructClaimsFromSlice(
+		jwt.String("name", "top"),
+		jwt.Namespace("profile"),
+		jwt.String("name", "nested"),
+		jwt.String(jwt.Subject, "nested subject"),
+		jwt.Skip(),
+		jwt.Error(nil),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	out, err := json.Marshal(claims.Set)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "json.Marshal()", string(out), `{"name":"top","profile":{"name":"nested"}}`)
+	expectString(t, "claims.Subject", claims.Subject, "nested subject")
+}
+
+func TestConstructClaimsFromSlice_ShouldFail(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim jwt.Claim
+	}{
+		{"unknown", jwt.Claim{Key: "key"}},
+		{"nan", jwt.Float64("key", math.NaN())},
+		{"infinity", jwt.Float32("key", float32(math.Inf(1)))},
+		{"reflect", jwt.Reflect("key", make(chan int))},
+		{"object", jwt.Object("key", jwt.ObjectMarshalerFunc(func() (map[string]interface{}, error) {
+			return nil, errors.New("failed")
+		}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.ConstructClaimsFromSlice(tt.claim)
+			if err == nil {
+				t.Errorf("expected error, returned nil")
+			}
+		})
+	}
+}
+
+func TestAny_SignAndVerify(t *testing.T) {
+	result := createResult(t,
+		jwt.Any("ratio", 0.5),
+		jwt.Any("limit", uint32(10)),
+		jwt.Any("ttl", 2*time.Hour),
+		jwt.Any("payload", []byte("hello")),
+		jwt.Any("meta", map[string]interface{}{"team": "core"}),
+	)
+
+	if v, err := result.GetFloat64("ratio"); err != nil || v != 0.5 {
+		t.Errorf("GetFloat64(ratio): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetInt64("limit"); err != nil || v != 10 {
+		t.Errorf("GetInt64(limit): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetDuration("ttl"); err != nil || v != 2*time.Hour {
+		t.Errorf("GetDuration(ttl): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetString("payload"); err != nil || v != "aGVsbG8" {
+		t.Errorf("GetString(payload): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetObject("meta"); err != nil || v["team"] != "core" {
+		t.Errorf("GetObject(meta): returned '%v', '%v'", v, err)
+	}
+}
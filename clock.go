@@ -0,0 +1,35 @@
+package jwt
+
+import "time"
+
+// Clock provides the current time to signers and verifiers.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to allow the use of ordinary functions as a `Clock`.
+type ClockFunc func() time.Time
+
+// Now returns the result of calling f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the `Clock` that returns the current local time.
+//
+//nolint:gochecknoglobals // stateless clock.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a `Clock` that always returns the time supplied.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// clockNow returns the current time from the clock, or the system time if the clock is nil.
+func clockNow(clock Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+
+	return clock.Now()
+}
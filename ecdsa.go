@@ -0,0 +1,267 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
+)
+
+// ErrUnsupportedCurve is returned when an ECDSA key uses a curve that has no matching JWT algorithm.
+var ErrUnsupportedCurve = errors.New("unsupported elliptic curve")
+
+// ECDSASigner implements the `Signer` interface and creates a token signed with an ECDSA private key.
+//
+// Clock, Lifetime and DefaultClaims are applied to every token in the same way as the `RSASigner`.
+type ECDSASigner struct {
+	PrivateKey    *ecdsa.PrivateKey
+	Issuer        string
+	Algorithm     string
+	KeyID         string
+	Clock         Clock
+	Lifetime      time.Duration
+	DefaultClaims []Claim
+}
+
+// NewECDSASignerFromFile returns an `ECDSASigner` initialized with the ECDSA Private Key supplied,
+// the algorithm is selected to match the curve of the key.
+func NewECDSASignerFromFile(filename string) (Signer, error) {
+	return NewECDSASignerFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// NewECDSASignerFromFileAFS returns an `ECDSASigner` initialized with the ECDSA Private Key supplied
+// with a supplied `afero.Fs`, the algorithm is selected to match the curve of the key.
+func NewECDSASignerFromFileAFS(afs afero.Fs, filename string) (Signer, error) {
+	privateKey, err := ParseECPrivateKeyFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return newECDSASigner(privateKey)
+}
+
+// NewECDSASignerFromEncryptedFile returns an `ECDSASigner` initialized with the encrypted ECDSA Private Key
+// supplied, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewECDSASignerFromEncryptedFile(filename string, passphrase PassphraseFunc) (Signer, error) {
+	return NewECDSASignerFromEncryptedFileAFS(afero.NewOsFs(), filename, passphrase)
+}
+
+// NewECDSASignerFromEncryptedFileAFS returns an `ECDSASigner` initialized with the encrypted ECDSA Private Key
+// supplied with a supplied `afero.Fs`, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewECDSASignerFromEncryptedFileAFS(afs afero.Fs, filename string, passphrase PassphraseFunc) (Signer, error) {
+	key, err := ParseEncryptedPrivateKeyFromFileAFS(afs, filename, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return newECDSASigner(privateKey)
+}
+
+// NewECDSASignerFromJWK returns an `ECDSASigner` initialized with the ECDSA Private Key in the JWK supplied,
+// the algorithm is taken from the JWK or selected to match the curve of the key.
+func NewECDSASignerFromJWK(jwk JWK) (Signer, error) {
+	privateKey, ok := jwk.Key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	alg := jwk.Algorithm
+	if alg == "" {
+		var err error
+		if alg, err = ECDSAAlgorithm(privateKey.Curve); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ECDSASigner{
+		PrivateKey: privateKey,
+		Algorithm:  alg,
+		KeyID:      jwk.KeyID,
+	}, nil
+}
+
+func newECDSASigner(privateKey *ecdsa.PrivateKey) (Signer, error) {
+	alg, err := ECDSAAlgorithm(privateKey.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ECDSASigner{
+		PrivateKey: privateKey,
+		Algorithm:  alg,
+	}, nil
+}
+
+// ECDSAAlgorithm returns the JWT algorithm matching the supplied elliptic curve.
+func ECDSAAlgorithm(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return ES256, nil
+	case elliptic.P384():
+		return ES384, nil
+	case elliptic.P521():
+		return ES512, nil
+	default:
+		return "", ErrUnsupportedCurve
+	}
+}
+
+// JWK returns the private key of the signer as a JWK with the key ID set to the signers KeyID,
+// or the RFC 7638 thumbprint if it is not set.
+func (e *ECDSASigner) JWK() (JWK, error) {
+	return newSignerJWK(e.PrivateKey, e.KeyID, e.Algorithm)
+}
+
+// SignClaims takes a list of claims and produces a signed token.
+func (e *ECDSASigner) SignClaims(claims ...Claim) ([]byte, error) {
+	tokenClaims, err := constructSignerClaims(signerPolicy{
+		issuer:   e.Issuer,
+		keyID:    e.KeyID,
+		clock:    e.Clock,
+		claims:   e.DefaultClaims,
+		lifetime: e.Lifetime,
+	}, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// pascaljwt serializes r and s a machine word at a time, when the parameter size of the curve
+	// is not a whole number of words (P-521) and r is small enough to need one word less, the
+	// leading bytes of r are left unset. The signature is checked and signing retried in that case.
+	paramLen := (e.PrivateKey.Curve.Params().BitSize + 7) / 8 //nolint:mnd // bits to bytes.
+	checkSignature := paramLen%(strconv.IntSize/8) != 0       //nolint:mnd // bits to bytes.
+
+	for range maxECDSASignAttempts {
+		token, err := tokenClaims.ECDSASign(e.Algorithm, e.PrivateKey)
+		if err != nil {
+			return token, fmt.Errorf("unable to sign claims: %w", err)
+		}
+
+		if !checkSignature {
+			return token, nil
+		}
+
+		if _, err := pascaljwt.ECDSACheck(token, &e.PrivateKey.PublicKey); err == nil {
+			return token, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to sign claims: %w", ErrSignatureInvalid)
+}
+
+// maxECDSASignAttempts is the number of times signing is attempted when the signature is checked.
+const maxECDSASignAttempts = 8
+
+// ECDSAVerifier implements the `Verifier` interface and tests a token signed with an ECDSA private key.
+//
+// Issuer, Issuers, Leeway, Clock, RequiredClaims, MaxLifetime, MaxAge, Schema, Revocation and
+// ReplayStore are applied to the token claims in the same way as the `RSAVerifier`.
+//
+// Algorithms restricts the accepted token algorithms, when empty all ECDSA algorithms are accepted.
+type ECDSAVerifier struct {
+	PublicKey      *ecdsa.PublicKey
+	Issuer         string
+	Issuers        []string
+	Audiences      []string
+	Leeway         time.Duration
+	Clock          Clock
+	RequiredClaims []string
+	MaxLifetime    time.Duration
+	MaxAge         time.Duration
+	Schema         Schema
+	Revocation     RevocationChecker
+	ReplayStore    ReplayStore
+	Algorithms     []string
+}
+
+// ecdsaAlgorithms are the algorithms accepted by the `ECDSAVerifier` when none are specified.
+var ecdsaAlgorithms = []string{ES256, ES384, ES512}
+
+// NewECDSAVerifierFromFile returns an `ECDSAVerifier` initialized with the ECDSA Public Key
+// supplied and an audience for token verification.
+func NewECDSAVerifierFromFile(audiences []string, filename string) (Verifier, error) {
+	return NewECDSAVerifierFromFileAFS(afero.NewOsFs(), audiences, filename)
+}
+
+// NewECDSAVerifierFromFileAFS returns an `ECDSAVerifier` initialized with the ECDSA Public Key
+// supplied with a supplied `afero.Fs` and an audience for token verification.
+func NewECDSAVerifierFromFileAFS(afs afero.Fs, audiences []string, filename string) (Verifier, error) {
+	publicKey, err := ParseECPublicKeyFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ECDSAVerifier{
+		Audiences: audiences,
+		PublicKey: publicKey,
+	}, nil
+}
+
+// NewECDSAVerifierFromJWK returns an `ECDSAVerifier` initialized with the ECDSA Public Key in the JWK
+// supplied and an audience for token verification, if the JWK specifies an algorithm only that
+// algorithm is accepted.
+func NewECDSAVerifierFromJWK(audiences []string, jwk JWK) (Verifier, error) {
+	publicJWK, err := jwk.Public()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := publicJWK.Key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
+	}
+
+	return &ECDSAVerifier{
+		Audiences:  audiences,
+		PublicKey:  publicKey,
+		Algorithms: jwkAlgorithms(jwk),
+	}, nil
+}
+
+// JWK returns the public key of the verifier as a JWK with the key ID set to the RFC 7638 thumbprint.
+func (v *ECDSAVerifier) JWK() (JWK, error) {
+	return NewJWK(v.PublicKey, KeyUseSignature, singleAlgorithm(v.Algorithms))
+}
+
+// Verify takes the token and checks it's signature against the ECDSA public key,
+// and the audience, notbefore and expires validity.
+func (v *ECDSAVerifier) Verify(token []byte) (VerifyResult, error) {
+	return v.VerifyWithChecks(token)
+}
+
+// VerifyWithChecks verifies the token in the same way as Verify, running the checks supplied
+// before the token ID is recorded by the ReplayStore.
+func (v *ECDSAVerifier) VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error) {
+	if _, err := checkAlgorithm(token, v.Algorithms, ecdsaAlgorithms); err != nil {
+		return VerifyResult{}, err
+	}
+
+	claims, err := pascaljwt.ECDSACheck(token, v.PublicKey)
+	if err != nil {
+		return VerifyResult{}, checkError(err)
+	}
+
+	return verifyClaims(claims, claimsPolicy{
+		issuers:    acceptedIssuers(v.Issuer, v.Issuers),
+		audiences:  v.Audiences,
+		leeway:     v.Leeway,
+		clock:      v.Clock,
+		required:   v.RequiredClaims,
+		lifetime:   v.MaxLifetime,
+		maxAge:     v.MaxAge,
+		schema:     v.Schema,
+		revocation: v.Revocation,
+		replay:     v.ReplayStore,
+		checks:     checks,
+	})
+}
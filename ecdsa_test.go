@@ -0,0 +1,148 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestECDSASigner_ShouldSucceed(t *testing.T) {
+	signer := createECDSASigner(t)
+	verifier := createECDSAVerifier(t)
+
+	nbfTime := time.Now().UTC()
+	expTime := time.Now().Add(time.Hour).UTC()
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "subject", true, nbfTime, expTime)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if len(token) == 0 {
+		t.Error("expected token not to be empty")
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectBool(t, "result.IsOnline", result.IsOnline, true)
+	expectString(t, "result.Subject", result.Subject, "subject")
+	expectStringNotEmpty(t, "result.ID", result.ID)
+	expectStringElement(t, "result.Audience[test-audience]", result.Audience, "test-audience")
+	expectTimeVaguelyEqual(t, "result.NotBefore", result.NotBefore, nbfTime)
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, expTime)
+	expectClaim(t, "result.Claims[sub]", result.Claims, jwt.String(jwt.Subject, "subject"))
+}
+
+func TestECDSASigner_AlgorithmFromCurve(t *testing.T) {
+	tests := []struct {
+		name  string
+		curve elliptic.Curve
+		alg   string
+	}{
+		{"P-256", elliptic.P256(), jwt.ES256},
+		{"P-384", elliptic.P384(), jwt.ES384},
+		{"P-521", elliptic.P521(), jwt.ES512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := ecdsa.GenerateKey(tt.curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			alg, err := jwt.ECDSAAlgorithm(tt.curve)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "alg", alg, tt.alg)
+
+			signer := &jwt.ECDSASigner{PrivateKey: privateKey, Algorithm: alg}
+			verifier := &jwt.ECDSAVerifier{PublicKey: &privateKey.PublicKey, Audiences: []string{"test-audience"}}
+
+			token, err := jwt.Sign(
+				signer,
+				[]string{"test-audience"},
+				"test-subject",
+				false,
+				time.Now(), time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := verifier.Verify(token)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "result.Subject", result.Subject, "test-subject")
+		})
+	}
+}
+
+func TestECDSAVerifier_ShouldFail_RSAToken(t *testing.T) {
+	signer := createSigner(t)
+	verifier := createECDSAVerifier(t)
+
+	token, err := jwt.Sign(
+		signer,
+		[]string{"test-audience"},
+		"test-subject",
+		false,
+		time.Now(), time.Now().Add(time.Hour),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrAlgorithmNotAllowed", err, jwt.ErrAlgorithmNotAllowed)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
+func TestECDSAVerifier_AudienceShouldFail(t *testing.T) {
+	signer := createECDSASigner(t)
+	verifier := createECDSAVerifier(t)
+
+	token, err := jwt.Sign(
+		signer,
+		[]string{"not-audience"},
+		"test-subject",
+		false,
+		time.Now(), time.Now().Add(time.Hour),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrTokenInvalidAudience", err, jwt.ErrTokenInvalidAudience)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
+func TestECDSAVerifier_Expired(t *testing.T) {
+	signer := createECDSASigner(t)
+	verifier := createECDSAVerifier(t)
+
+	token, err := jwt.Sign(
+		signer,
+		[]string{"test-audience"},
+		"test-subject",
+		false,
+		time.Now().Add(-1*time.Hour),
+		time.Now().Add(-1*time.Minute),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrTokenTimeNotValid", err, jwt.ErrTokenTimeNotValid)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
@@ -0,0 +1,207 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
+)
+
+// EdDSASigner implements the `Signer` interface and creates a token signed with an Ed25519 private key.
+//
+// Clock, Lifetime and DefaultClaims are applied to every token in the same way as the `RSASigner`.
+type EdDSASigner struct {
+	PrivateKey    ed25519.PrivateKey
+	Issuer        string
+	KeyID         string
+	Clock         Clock
+	Lifetime      time.Duration
+	DefaultClaims []Claim
+}
+
+// NewEdDSASignerFromFile returns an `EdDSASigner` initialized with the Ed25519 Private Key supplied.
+func NewEdDSASignerFromFile(filename string) (Signer, error) {
+	return NewEdDSASignerFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// NewEdDSASignerFromFileAFS returns an `EdDSASigner` initialized with the Ed25519 Private Key supplied
+// with a supplied `afero.Fs`.
+func NewEdDSASignerFromFileAFS(afs afero.Fs, filename string) (Signer, error) {
+	privateKey, err := ParseEd25519PrivateKeyFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdDSASigner{
+		PrivateKey: privateKey,
+	}, nil
+}
+
+// NewEdDSASignerFromEncryptedFile returns an `EdDSASigner` initialized with the encrypted Ed25519 Private Key
+// supplied, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewEdDSASignerFromEncryptedFile(filename string, passphrase PassphraseFunc) (Signer, error) {
+	return NewEdDSASignerFromEncryptedFileAFS(afero.NewOsFs(), filename, passphrase)
+}
+
+// NewEdDSASignerFromEncryptedFileAFS returns an `EdDSASigner` initialized with the encrypted Ed25519 Private Key
+// supplied with a supplied `afero.Fs`, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewEdDSASignerFromEncryptedFileAFS(afs afero.Fs, filename string, passphrase PassphraseFunc) (Signer, error) {
+	key, err := ParseEncryptedPrivateKeyFromFileAFS(afs, filename, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return &EdDSASigner{
+		PrivateKey: privateKey,
+	}, nil
+}
+
+// NewEdDSASignerFromJWK returns an `EdDSASigner` initialized with the Ed25519 Private Key in the JWK supplied.
+func NewEdDSASignerFromJWK(jwk JWK) (Signer, error) {
+	privateKey, ok := jwk.Key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return &EdDSASigner{
+		PrivateKey: privateKey,
+		KeyID:      jwk.KeyID,
+	}, nil
+}
+
+// JWK returns the private key of the signer as a JWK with the key ID set to the signers KeyID,
+// or the RFC 7638 thumbprint if it is not set.
+func (e *EdDSASigner) JWK() (JWK, error) {
+	return newSignerJWK(e.PrivateKey, e.KeyID, EdDSA)
+}
+
+// SignClaims takes a list of claims and produces a signed token.
+func (e *EdDSASigner) SignClaims(claims ...Claim) ([]byte, error) {
+	tokenClaims, err := constructSignerClaims(signerPolicy{
+		issuer:   e.Issuer,
+		keyID:    e.KeyID,
+		clock:    e.Clock,
+		claims:   e.DefaultClaims,
+		lifetime: e.Lifetime,
+	}, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenClaims.EdDSASign(e.PrivateKey)
+	if err != nil {
+		return token, fmt.Errorf("unable to sign claims: %w", err)
+	}
+
+	return token, nil
+}
+
+// EdDSAVerifier implements the `Verifier` interface and tests a token signed with an Ed25519 private key.
+//
+// Issuer, Issuers, Leeway, Clock, RequiredClaims, MaxLifetime, MaxAge, Schema, Revocation and
+// ReplayStore are applied to the token claims in the same way as the `RSAVerifier`.
+//
+// Algorithms restricts the accepted token algorithms, when empty only EdDSA is accepted.
+type EdDSAVerifier struct {
+	PublicKey      ed25519.PublicKey
+	Issuer         string
+	Issuers        []string
+	Audiences      []string
+	Leeway         time.Duration
+	Clock          Clock
+	RequiredClaims []string
+	MaxLifetime    time.Duration
+	MaxAge         time.Duration
+	Schema         Schema
+	Revocation     RevocationChecker
+	ReplayStore    ReplayStore
+	Algorithms     []string
+}
+
+// eddsaAlgorithms are the algorithms accepted by the `EdDSAVerifier` when none are specified.
+var eddsaAlgorithms = []string{EdDSA}
+
+// NewEdDSAVerifierFromFile returns an `EdDSAVerifier` initialized with the Ed25519 Public Key
+// supplied and an audience for token verification.
+func NewEdDSAVerifierFromFile(audiences []string, filename string) (Verifier, error) {
+	return NewEdDSAVerifierFromFileAFS(afero.NewOsFs(), audiences, filename)
+}
+
+// NewEdDSAVerifierFromFileAFS returns an `EdDSAVerifier` initialized with the Ed25519 Public Key
+// supplied with a supplied `afero.Fs` and an audience for token verification.
+func NewEdDSAVerifierFromFileAFS(afs afero.Fs, audiences []string, filename string) (Verifier, error) {
+	publicKey, err := ParseEd25519PublicKeyFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdDSAVerifier{
+		Audiences: audiences,
+		PublicKey: publicKey,
+	}, nil
+}
+
+// NewEdDSAVerifierFromJWK returns an `EdDSAVerifier` initialized with the Ed25519 Public Key in the JWK
+// supplied and an audience for token verification.
+func NewEdDSAVerifierFromJWK(audiences []string, jwk JWK) (Verifier, error) {
+	publicJWK, err := jwk.Public()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := publicJWK.Key.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
+	}
+
+	return &EdDSAVerifier{
+		Audiences:  audiences,
+		PublicKey:  publicKey,
+		Algorithms: jwkAlgorithms(jwk),
+	}, nil
+}
+
+// JWK returns the public key of the verifier as a JWK with the key ID set to the RFC 7638 thumbprint.
+func (v *EdDSAVerifier) JWK() (JWK, error) {
+	return NewJWK(v.PublicKey, KeyUseSignature, EdDSA)
+}
+
+// Verify takes the token and checks it's signature against the Ed25519 public key,
+// and the audience, notbefore and expires validity.
+func (v *EdDSAVerifier) Verify(token []byte) (VerifyResult, error) {
+	return v.VerifyWithChecks(token)
+}
+
+// VerifyWithChecks verifies the token in the same way as Verify, running the checks supplied
+// before the token ID is recorded by the ReplayStore.
+func (v *EdDSAVerifier) VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error) {
+	if _, err := checkAlgorithm(token, v.Algorithms, eddsaAlgorithms); err != nil {
+		return VerifyResult{}, err
+	}
+
+	claims, err := pascaljwt.EdDSACheck(token, v.PublicKey)
+	if err != nil {
+		return VerifyResult{}, checkError(err)
+	}
+
+	return verifyClaims(claims, claimsPolicy{
+		issuers:    acceptedIssuers(v.Issuer, v.Issuers),
+		audiences:  v.Audiences,
+		leeway:     v.Leeway,
+		clock:      v.Clock,
+		required:   v.RequiredClaims,
+		lifetime:   v.MaxLifetime,
+		maxAge:     v.MaxAge,
+		schema:     v.Schema,
+		revocation: v.Revocation,
+		replay:     v.ReplayStore,
+		checks:     checks,
+	})
+}
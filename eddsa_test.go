@@ -0,0 +1,97 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestEdDSASigner_ShouldSucceed(t *testing.T) {
+	signer := createEdDSASigner(t)
+	verifier := createEdDSAVerifier(t)
+
+	nbfTime := time.Now().UTC()
+	expTime := time.Now().Add(time.Hour).UTC()
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "subject", true, nbfTime, expTime)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if len(token) == 0 {
+		t.Error("expected token not to be empty")
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectBool(t, "result.IsOnline", result.IsOnline, true)
+	expectString(t, "result.Subject", result.Subject, "subject")
+	expectStringNotEmpty(t, "result.ID", result.ID)
+	expectStringElement(t, "result.Audience[test-audience]", result.Audience, "test-audience")
+	expectTimeVaguelyEqual(t, "result.NotBefore", result.NotBefore, nbfTime)
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, expTime)
+	expectClaim(t, "result.Claims[sub]", result.Claims, jwt.String(jwt.Subject, "subject"))
+}
+
+func TestEdDSAVerifier_ShouldSucceed_PKIXPublicKey(t *testing.T) {
+	signer := createEdDSASigner(t)
+	verifier, err := jwt.NewEdDSAVerifierFromFileAFS(createAfs(), []string{"test-audience"}, "ed-pub.pem")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "subject", false, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	expectString(t, "result.Subject", result.Subject, "subject")
+}
+
+func TestEdDSAVerifier_ShouldFail_RSAToken(t *testing.T) {
+	signer := createSigner(t)
+	verifier := createEdDSAVerifier(t)
+
+	token, err := jwt.Sign(
+		signer,
+		[]string{"test-audience"},
+		"test-subject",
+		false,
+		time.Now(), time.Now().Add(time.Hour),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrAlgorithmNotAllowed", err, jwt.ErrAlgorithmNotAllowed)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
+func TestEdDSAVerifier_NotValidYet(t *testing.T) {
+	signer := createEdDSASigner(t)
+	verifier := createEdDSAVerifier(t)
+
+	token, err := jwt.Sign(
+		signer,
+		[]string{"test-audience"},
+		"test-subject",
+		false,
+		time.Now().Add(time.Minute),
+		time.Now().Add(time.Hour),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrTokenTimeNotValid", err, jwt.ErrTokenTimeNotValid)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
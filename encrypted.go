@@ -0,0 +1,321 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des" //nolint:gosec // 3DES is only used to decrypt existing keys.
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA-1 is the PKCS#5 default PRF.
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/spf13/afero"
+)
+
+// ErrPrivateKeyNotEncrypted is returned when an encrypted private key was expected but the key is plain text.
+var ErrPrivateKeyNotEncrypted = errors.New("private key is not encrypted")
+
+// ErrDecryptPrivateKey is returned when an encrypted private key could not be decrypted,
+// usually because of an incorrect passphrase.
+var ErrDecryptPrivateKey = errors.New("unable to decrypt private key")
+
+// ErrUnsupportedEncryption is returned when an encrypted private key uses an unsupported
+// key derivation function or cipher.
+var ErrUnsupportedEncryption = errors.New("unsupported private key encryption")
+
+// PassphraseFunc returns the passphrase used to decrypt an encrypted private key,
+// the returned slice is wiped after it has been used.
+type PassphraseFunc func() ([]byte, error)
+
+// Passphrase returns a `PassphraseFunc` that supplies the passphrase, the passphrase
+// slice is wiped after it has been used so it can only be used once.
+func Passphrase(passphrase []byte) PassphraseFunc {
+	return func() ([]byte, error) {
+		return passphrase, nil
+	}
+}
+
+const (
+	pemTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+
+	// maxPBKDF2Iterations is the largest PBKDF2 iteration count accepted when decrypting a
+	// private key, higher counts are rejected so a crafted key can not stall the loader.
+	maxPBKDF2Iterations = 10_000_000
+)
+
+// ParseEncryptedPrivateKeyFromFile parses an encrypted Private Key from a PEM file.
+func ParseEncryptedPrivateKeyFromFile(filename string, passphrase PassphraseFunc) (crypto.Signer, error) {
+	return ParseEncryptedPrivateKeyFromFileAFS(afero.NewOsFs(), filename, passphrase)
+}
+
+// ParseEncryptedPrivateKeyFromFileAFS parses an encrypted Private Key from a PEM file with a supplied `afero.Fs`.
+func ParseEncryptedPrivateKeyFromFileAFS(
+	afs afero.Fs,
+	filename string,
+	passphrase PassphraseFunc,
+) (crypto.Signer, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+
+	return ParseEncryptedPrivateKey(data, passphrase)
+}
+
+// ParseEncryptedPrivateKey parses an encrypted Private Key from a byte slice containing either
+// a PKCS8 "ENCRYPTED PRIVATE KEY" block or a legacy encrypted PEM block (with a "Proc-Type: 4,ENCRYPTED"
+// header), the passphrase is requested from the `PassphraseFunc` and wiped after use.
+//
+// Unencrypted keys are rejected with `ErrPrivateKeyNotEncrypted`.
+func ParseEncryptedPrivateKey(data []byte, passphrase PassphraseFunc) (crypto.Signer, error) {
+	privatePem, _ := pem.Decode(data)
+	if privatePem == nil {
+		return nil, ErrDecodePEM
+	}
+
+	//nolint:staticcheck // legacy encrypted PEM is insecure by design but still supported for loading.
+	legacy := x509.IsEncryptedPEMBlock(privatePem)
+	if privatePem.Type != pemTypeEncryptedPrivateKey && !legacy {
+		return nil, ErrPrivateKeyNotEncrypted
+	}
+
+	pass, err := passphrase()
+	defer wipe(pass)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get passphrase: %w", err)
+	}
+
+	if legacy {
+		//nolint:staticcheck // legacy encrypted PEM is insecure by design but still supported for loading.
+		der, err := x509.DecryptPEMBlock(privatePem, pass)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecryptPrivateKey, err)
+		}
+		defer wipe(der)
+
+		return parsePrivateKeyBlock(privatePem.Type, der)
+	}
+
+	der, err := decryptPKCS8(privatePem.Bytes, pass)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(der)
+
+	key, err := parsePrivateKeyBlock(pemTypePrivateKey, der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptPrivateKey, err)
+	}
+
+	return key, nil
+}
+
+// wipe overwrites the supplied slice with zeros.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo is the PKCS8 EncryptedPrivateKeyInfo structure (RFC 5208).
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params is the PBES2-params structure (RFC 8018).
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is the PBKDF2-params structure (RFC 8018).
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts a PBES2 encrypted PKCS8 private key and returns the DER encoded PrivateKeyInfo.
+func decryptPKCS8(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncryption, info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	block, keyLen, err := pbes2Cipher(params.EncryptionScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	key, err := pbes2Key(params.KeyDerivationFunc, passphrase, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(key)
+
+	c, err := block(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptPrivateKey, err)
+	}
+
+	data := info.EncryptedData
+	if len(iv) != c.BlockSize() || len(data) == 0 || len(data)%c.BlockSize() != 0 {
+		return nil, ErrDecryptPrivateKey
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(c, iv).CryptBlocks(plain, data)
+
+	return unpad(plain, c.BlockSize())
+}
+
+// pbes2Cipher returns the block cipher constructor and key length for a PBES2 encryption scheme.
+func pbes2Cipher(oid asn1.ObjectIdentifier) (func([]byte) (cipher.Block, error), int, error) {
+	switch {
+	case oid.Equal(oidAES128CBC):
+		return aes.NewCipher, 16, nil //nolint:mnd // AES-128 key length.
+	case oid.Equal(oidAES192CBC):
+		return aes.NewCipher, 24, nil //nolint:mnd // AES-192 key length.
+	case oid.Equal(oidAES256CBC):
+		return aes.NewCipher, 32, nil //nolint:mnd // AES-256 key length.
+	case oid.Equal(oidDESEDE3CBC):
+		return des.NewTripleDESCipher, 24, nil //nolint:mnd // 3DES key length.
+	default:
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnsupportedEncryption, oid)
+	}
+}
+
+// pbes2Key derives the encryption key from the passphrase using PBKDF2.
+func pbes2Key(kdf pkix.AlgorithmIdentifier, passphrase []byte, keyLen int) ([]byte, error) {
+	if !kdf.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncryption, kdf.Algorithm)
+	}
+
+	var params pbkdf2Params
+	if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	var prf func() hash.Hash
+
+	switch oid := params.PRF.Algorithm; {
+	case len(oid) == 0, oid.Equal(oidHMACSHA1):
+		prf = sha1.New
+	case oid.Equal(oidHMACSHA224):
+		prf = sha256.New224
+	case oid.Equal(oidHMACSHA256):
+		prf = sha256.New
+	case oid.Equal(oidHMACSHA384):
+		prf = sha512.New384
+	case oid.Equal(oidHMACSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncryption, oid)
+	}
+
+	if params.KeyLength != 0 && params.KeyLength != keyLen {
+		return nil, fmt.Errorf("%w: key length %d", ErrUnsupportedEncryption, params.KeyLength)
+	}
+
+	if params.IterationCount < 1 || params.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("%w: iteration count %d", ErrUnsupportedEncryption, params.IterationCount)
+	}
+
+	return pbkdf2(passphrase, params.Salt, params.IterationCount, keyLen, prf), nil
+}
+
+// pbkdf2 implements the PBKDF2 key derivation function from RFC 8018.
+func pbkdf2(password, salt []byte, iter, keyLen int, h func() hash.Hash) []byte {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block)) //nolint:gosec // block count is bounded by keyLen.
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+
+			for x := range u {
+				t[x] ^= u[x]
+			}
+		}
+	}
+
+	wipe(u)
+
+	return dk[:keyLen]
+}
+
+// unpad removes and validates PKCS#7 padding.
+func unpad(data []byte, blockSize int) ([]byte, error) {
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) {
+		wipe(data)
+
+		return nil, ErrDecryptPrivateKey
+	}
+
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			wipe(data)
+
+			return nil, ErrDecryptPrivateKey
+		}
+	}
+
+	return data[:len(data)-n], nil
+}
@@ -0,0 +1,175 @@
+package jwt_test
+
+import (
+	"crypto/rsa"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestEncryptedPrivateKey_ShouldSucceed(t *testing.T) {
+	tests := []struct {
+		name, filename, keyType string
+	}{
+		{"RSA PKCS8 AES-256", "rsa-enc.pem", "*rsa.PrivateKey"},
+		{"RSA legacy PEM", "rsa-legacy.pem", "*rsa.PrivateKey"},
+		{"EC PKCS8 AES-128", "ec-enc.pem", "*ecdsa.PrivateKey"},
+		{"Ed25519 PKCS8 3DES", "ed-enc.pem", "ed25519.PrivateKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := jwt.ParseEncryptedPrivateKeyFromFileAFS(
+				createAfs(),
+				tt.filename,
+				jwt.Passphrase([]byte(testPassphrase)),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			if v := reflect.TypeOf(privateKey).String(); v != tt.keyType {
+				t.Errorf("expected privateKey to be type(%s), received type(%s)", tt.keyType, v)
+			}
+		})
+	}
+}
+
+func TestEncryptedPrivateKey_MatchesPlainKey(t *testing.T) {
+	encrypted, err := jwt.ParseEncryptedPrivateKey(
+		[]byte(rsaEncryptedPrivateKey),
+		jwt.Passphrase([]byte(testPassphrase)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	plain, err := jwt.ParsePrivateKey([]byte(rsaPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	publicKey, ok := plain.Public().(*rsa.PublicKey)
+	if !ok || !publicKey.Equal(encrypted.Public()) {
+		t.Error("expected decrypted key to match plain text key")
+	}
+}
+
+func TestEncryptedPrivateKey_WipesPassphrase(t *testing.T) {
+	passphrase := []byte(testPassphrase)
+
+	_, err := jwt.ParseEncryptedPrivateKey([]byte(ecEncryptedPrivateKey), jwt.Passphrase(passphrase))
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	for _, b := range passphrase {
+		if b != 0 {
+			t.Fatalf("expected passphrase to be wiped, received '%s'", passphrase)
+		}
+	}
+}
+
+func TestEncryptedPrivateKey_ShouldFail(t *testing.T) {
+	errCallback := errors.New("callback failed")
+
+	tests := []struct {
+		name       string
+		data       string
+		passphrase jwt.PassphraseFunc
+		expect     error
+	}{
+		{"wrong passphrase PKCS8", rsaEncryptedPrivateKey, jwt.Passphrase([]byte("wrong")), jwt.ErrDecryptPrivateKey},
+		{"wrong passphrase legacy", rsaLegacyEncryptedPrivateKey, jwt.Passphrase([]byte("wrong")), jwt.ErrDecryptPrivateKey},
+		{"not encrypted", rsaPrivateKey, jwt.Passphrase([]byte(testPassphrase)), jwt.ErrPrivateKeyNotEncrypted},
+		{"not PEM", "garbage", jwt.Passphrase([]byte(testPassphrase)), jwt.ErrDecodePEM},
+		{
+			"excessive iterations", ecExcessiveIterationsPrivateKey,
+			jwt.Passphrase([]byte(testPassphrase)), jwt.ErrUnsupportedEncryption,
+		},
+		{
+			"callback error", edEncryptedPrivateKey,
+			func() ([]byte, error) { return nil, errCallback },
+			errCallback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := jwt.ParseEncryptedPrivateKey([]byte(tt.data), tt.passphrase)
+			expectErrMatch(t, tt.name, err, tt.expect)
+			if privateKey != nil {
+				t.Error("expected privateKey to be nil, but returned non-nil")
+			}
+		})
+	}
+}
+
+func TestEncryptedPrivateKey_Signers(t *testing.T) {
+	tests := []struct {
+		name     string
+		signer   func() (jwt.Signer, error)
+		verifier jwt.Verifier
+	}{
+		{
+			"RSA",
+			func() (jwt.Signer, error) {
+				return jwt.NewRSASignerFromEncryptedFileAFS(
+					createAfs(), "rsa-legacy.pem", jwt.Passphrase([]byte(testPassphrase)),
+				)
+			},
+			createVerifier(t),
+		},
+		{
+			"ECDSA",
+			func() (jwt.Signer, error) {
+				return jwt.NewECDSASignerFromEncryptedFileAFS(
+					createAfs(), "ec-enc.pem", jwt.Passphrase([]byte(testPassphrase)),
+				)
+			},
+			createECDSAVerifier(t),
+		},
+		{
+			"EdDSA",
+			func() (jwt.Signer, error) {
+				return jwt.NewEdDSASignerFromEncryptedFileAFS(
+					createAfs(), "ed-enc.pem", jwt.Passphrase([]byte(testPassphrase)),
+				)
+			},
+			createEdDSAVerifier(t),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := tt.signer()
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			token, err := jwt.Sign(
+				signer,
+				[]string{"test-audience"},
+				"test-subject",
+				false,
+				time.Now(), time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := tt.verifier.Verify(token)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "result.Subject", result.Subject, "test-subject")
+		})
+	}
+}
+
+func TestEncryptedPrivateKey_Signers_WrongKeyType(t *testing.T) {
+	_, err := jwt.NewRSASignerFromEncryptedFileAFS(createAfs(), "ec-enc.pem", jwt.Passphrase([]byte(testPassphrase)))
+	expectErrMatch(t, "jwt.ErrExtractPrivateKey", err, jwt.ErrExtractPrivateKey)
+}
@@ -0,0 +1,81 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTokenExpired is the error returned when a token is verified at or after the Expires time.
+var ErrTokenExpired = errors.New("token has expired")
+
+// ErrTokenNotYetValid is the error returned when a token is verified before the NotBefore or Issued time.
+var ErrTokenNotYetValid = errors.New("token is not yet valid")
+
+// ErrSignatureInvalid is the error returned when the token signature does not match the key.
+var ErrSignatureInvalid = errors.New("token signature is invalid")
+
+// ErrMalformedToken is the error returned when the token can not be decoded.
+var ErrMalformedToken = errors.New("token is malformed")
+
+// ErrTokenMissingClaim is the error returned when a required claim is not present in the token.
+var ErrTokenMissingClaim = errors.New("token is missing a required claim")
+
+// ErrTokenLifetimeExceeded is the error returned when the time between the Issued or NotBefore
+// and Expires times of a token is longer than the maximum lifetime.
+var ErrTokenLifetimeExceeded = errors.New("token lifetime exceeds the maximum")
+
+// ErrTokenTooOld is the error returned when a token was issued longer ago than the maximum age.
+var ErrTokenTooOld = errors.New("token is older than the maximum age")
+
+// VerificationError is the error returned when a token fails verification, the Reason is one
+// of the sentinel errors and can be tested with `errors.Is`, Claim is the name of the offending
+// claim or header.
+//
+// For time based failures Time is the time from the offending claim and CheckTime and Leeway
+// are the time and clock skew tolerance used for the check.
+type VerificationError struct {
+	Reason    error
+	Claim     string
+	Time      time.Time
+	CheckTime time.Time
+	Leeway    time.Duration
+	Err       error
+}
+
+// Error returns the reason followed by the claim and times when available.
+func (e *VerificationError) Error() string {
+	msg := e.Reason.Error()
+
+	if e.Claim != "" {
+		msg += ": " + e.Claim
+	}
+
+	if !e.Time.IsZero() {
+		msg += " " + e.Time.Format(time.RFC3339) + ", checked at " + e.CheckTime.Format(time.RFC3339)
+
+		if e.Leeway != 0 {
+			msg += " with leeway " + e.Leeway.String()
+		}
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Is reports whether the target is `ErrTokenTimeNotValid` for time based failures.
+func (e *VerificationError) Is(target error) bool {
+	return target == ErrTokenTimeNotValid && //nolint:errorlint // comparing sentinel errors.
+		(errors.Is(e.Reason, ErrTokenExpired) || errors.Is(e.Reason, ErrTokenNotYetValid))
+}
+
+// Unwrap returns the reason and the underlying error if there is one.
+func (e *VerificationError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Reason}
+	}
+
+	return []error{e.Reason, e.Err}
+}
@@ -0,0 +1,151 @@
+package jwt_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestVerificationError_TimeReasons(t *testing.T) {
+	issued := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Issued, issued),
+		jwt.Time(jwt.NotBefore, issued.Add(time.Minute)),
+		jwt.Time(jwt.Expires, issued.Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		reason error
+		claim  string
+		time   time.Time
+	}{
+		{"issued in future", issued.Add(-time.Second), jwt.ErrTokenNotYetValid, jwt.Issued, issued},
+		{"not before", issued.Add(time.Second), jwt.ErrTokenNotYetValid, jwt.NotBefore, issued.Add(time.Minute)},
+		{"expired", issued.Add(2 * time.Hour), jwt.ErrTokenExpired, jwt.Expires, issued.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.Clock = jwt.FixedClock(tt.now)
+
+			_, err := verifier.Verify(token)
+			expectErrMatch(t, "verifier.Verify()", err, tt.reason)
+			expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenTimeNotValid)
+
+			var verr *jwt.VerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected err '%v' to be a *jwt.VerificationError", err)
+			}
+
+			expectString(t, "verr.Claim", verr.Claim, tt.claim)
+
+			if !verr.Time.Equal(tt.time) {
+				t.Errorf("verr.Time: expected '%s', returned '%s'", tt.time, verr.Time)
+			}
+
+			if !verr.CheckTime.Equal(tt.now) {
+				t.Errorf("verr.CheckTime: expected '%s', returned '%s'", tt.now, verr.CheckTime)
+			}
+		})
+	}
+}
+
+func TestVerificationError_ExpiredIsNotNotYetValid(t *testing.T) {
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Expires, time.Now().Add(-time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = createVerifier(t).Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenExpired)
+
+	if errors.Is(err, jwt.ErrTokenNotYetValid) {
+		t.Errorf("expected err '%v' not to match '%v'", err, jwt.ErrTokenNotYetValid)
+	}
+}
+
+func TestVerificationError_SignatureInvalid(t *testing.T) {
+	token, err := createSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if token[len(token)-10] == 'A' {
+		token[len(token)-10] = 'B'
+	} else {
+		token[len(token)-10] = 'A'
+	}
+
+	_, err = createVerifier(t).Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrSignatureInvalid)
+}
+
+func TestVerificationError_MalformedToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"garbage", "garbage"},
+		{"bad header", "!!!.e30.e30"},
+		{"bad payload", "eyJhbGciOiJSUzI1NiJ9.!!!.e30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := createVerifier(t).Verify([]byte(tt.token))
+			expectErrMatch(t, "verifier.Verify()", err, jwt.ErrMalformedToken)
+		})
+	}
+}
+
+func TestVerificationError_AlgorithmNotAllowed(t *testing.T) {
+	token, err := createHMACSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = createVerifier(t).Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrAlgorithmNotAllowed)
+
+	var verr *jwt.VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected err '%v' to be a *jwt.VerificationError", err)
+	}
+
+	expectString(t, "verr.Claim", verr.Claim, "alg")
+
+	if !strings.Contains(err.Error(), `"HS256"`) {
+		t.Errorf("expected error message '%v' to contain '%s'", err, `"HS256"`)
+	}
+}
+
+func TestVerificationError_InvalidAudience(t *testing.T) {
+	token, err := createSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"other-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = createVerifier(t).Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenInvalidAudience)
+
+	var verr *jwt.VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected err '%v' to be a *jwt.VerificationError", err)
+	}
+
+	expectString(t, "verr.Claim", verr.Claim, jwt.Audience)
+}
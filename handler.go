@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeySetMaxAge is the Cache-Control max-age used by the `KeySetHandler` when none is specified.
+	DefaultKeySetMaxAge = 15 * time.Minute
+
+	// keySetContentType is the media type of a JWK Set.
+	keySetContentType = "application/jwk-set+json"
+)
+
+// JWKSigner is a `Signer` that can export it's key as a JWK.
+type JWKSigner interface {
+	Signer
+
+	JWK() (JWK, error)
+}
+
+// KeySetHandler is an `http.Handler` that publishes the public keys of signers as a JWK Set.
+//
+// Keys of retired signers are published until the end of their grace window so that tokens
+// issued before the key rotation can still be verified.
+type KeySetHandler struct {
+	MaxAge time.Duration
+
+	lock    sync.Mutex
+	keys    []JWK
+	retired []retiredKey
+}
+
+type retiredKey struct {
+	key   JWK
+	until time.Time
+}
+
+// NewKeySetHandler returns a `KeySetHandler` publishing the public keys of the signers supplied.
+func NewKeySetHandler(signers ...JWKSigner) (*KeySetHandler, error) {
+	h := &KeySetHandler{
+		MaxAge: DefaultKeySetMaxAge,
+	}
+
+	for _, signer := range signers {
+		if err := h.Add(signer); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Add publishes the public key of the signer.
+func (h *KeySetHandler) Add(signer JWKSigner) error {
+	key, err := signerPublicKey(signer)
+	if err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.keys = append(h.keys, key)
+
+	return nil
+}
+
+// Retire publishes the public key of the signer until the time supplied, if the signer was
+// added to the handler it is removed from the current keys.
+func (h *KeySetHandler) Retire(signer JWKSigner, until time.Time) error {
+	key, err := signerPublicKey(signer)
+	if err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	keys := h.keys[:0]
+
+	for _, k := range h.keys {
+		if k.KeyID != key.KeyID {
+			keys = append(keys, k)
+		}
+	}
+
+	h.keys = keys
+	h.retired = append(h.retired, retiredKey{key: key, until: until})
+
+	return nil
+}
+
+// KeySet returns the JWK Set of the current keys and retired keys still in their grace window.
+func (h *KeySetHandler) KeySet() JWKSet {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	now := time.Now()
+	set := JWKSet{Keys: append([]JWK{}, h.keys...)}
+	retired := h.retired[:0]
+
+	for _, r := range h.retired {
+		if now.Before(r.until) {
+			retired = append(retired, r)
+			set.Keys = append(set.Keys, r.key)
+		}
+	}
+
+	h.retired = retired
+
+	return set
+}
+
+// ServeHTTP writes the JWK Set, responding with "304 Not Modified" when the If-None-Match
+// request header matches the ETag of the key set.
+func (h *KeySetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := json.Marshal(h.KeySet())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+
+	maxAge := h.MaxAge
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.FormatInt(int64(maxAge/time.Second), 10))
+
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", keySetContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+// signerPublicKey returns the public JWK of the signer.
+func signerPublicKey(signer JWKSigner) (JWK, error) {
+	key, err := signer.JWK()
+	if err != nil {
+		return JWK{}, fmt.Errorf("unable to export signer key: %w", err)
+	}
+
+	return key.Public()
+}
+
+// etagMatch returns true if the If-None-Match header value matches the ETag.
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func createKeySetHandler(t *testing.T) (*jwt.KeySetHandler, []jwt.Signer) {
+	t.Helper()
+
+	_, signers := createKeySet(t)
+
+	handler, err := jwt.NewKeySetHandler(signers[0].(jwt.JWKSigner), signers[1].(jwt.JWKSigner))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return handler, signers
+}
+
+func TestKeySetHandler_ShouldSucceed(t *testing.T) {
+	handler, signers := createKeySetHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rec.Code: expected '%d', returned '%d'", http.StatusOK, rec.Code)
+	}
+
+	expectString(t, "Content-Type", rec.Header().Get("Content-Type"), "application/jwk-set+json")
+	expectString(t, "Cache-Control", rec.Header().Get("Cache-Control"), "public, max-age=900")
+	expectStringNotEmpty(t, "ETag", rec.Header().Get("ETag"))
+
+	var set jwt.JWKSet
+	if err := json.Unmarshal(rec.Body.Bytes(), &set); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if len(set.Keys) != 2 {
+		t.Fatalf("set.Keys: expected length '2', returned '%d'", len(set.Keys))
+	}
+
+	for _, key := range set.Keys {
+		expectBool(t, "key.IsPrivate()", key.IsPrivate(), false)
+	}
+
+	verifier := &jwt.KeySetVerifier{Keys: set, Audiences: []string{"test-audience"}}
+	if _, err := verifier.Verify(signKeySetToken(t, signers[1])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+}
+
+func TestKeySetHandler_ShouldSucceed_ConditionalGet(t *testing.T) {
+	handler, _ := createKeySetHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	etag := rec.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", etag)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("rec.Code: expected '%d', returned '%d'", http.StatusNotModified, rec.Code)
+	}
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("rec.Body: expected empty, returned '%s'", rec.Body.String())
+	}
+
+	req.Header.Set("If-None-Match", `"stale"`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("rec.Code: expected '%d', returned '%d'", http.StatusOK, rec.Code)
+	}
+}
+
+func TestKeySetHandler_ShouldSucceed_RetiredKeys(t *testing.T) {
+	handler, signers := createKeySetHandler(t)
+
+	if err := handler.Retire(signers[0].(jwt.JWKSigner), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if err := handler.Retire(signers[1].(jwt.JWKSigner), time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if err := handler.Add(signers[2].(jwt.JWKSigner)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	set := handler.KeySet()
+	if len(set.Keys) != 2 {
+		t.Fatalf("set.Keys: expected length '2', returned '%d'", len(set.Keys))
+	}
+
+	expectString(t, "set.Keys[0].KeyID", set.Keys[0].KeyID, "ed-key")
+	expectString(t, "set.Keys[1].KeyID", set.Keys[1].KeyID, "rsa-key")
+}
+
+func TestKeySetHandler_ShouldFail_MethodNotAllowed(t *testing.T) {
+	handler, _ := createKeySetHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("rec.Code: expected '%d', returned '%d'", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	expectString(t, "Allow", rec.Header().Get("Allow"), "GET, HEAD")
+}
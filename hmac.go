@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
+)
+
+// MinHMACSecretLength is the minimum length in bytes of a shared secret used by
+// the `HMACSigner` and `HMACVerifier`, this matches the output size of SHA-256.
+const MinHMACSecretLength = 32
+
+// ErrHMACSecretTooShort is returned when a shared secret is shorter than `MinHMACSecretLength`.
+var ErrHMACSecretTooShort = errors.New("hmac secret is too short")
+
+// HMACSigner implements the `Signer` interface and creates a token signed with a shared secret.
+//
+// Clock, Lifetime and DefaultClaims are applied to every token in the same way as the `RSASigner`.
+type HMACSigner struct {
+	Secret        []byte
+	Issuer        string
+	Algorithm     string
+	KeyID         string
+	Clock         Clock
+	Lifetime      time.Duration
+	DefaultClaims []Claim
+}
+
+// NewHMACSignerFromFile returns an `HMACSigner` initialized with the shared secret read from the file supplied.
+func NewHMACSignerFromFile(filename string) (Signer, error) {
+	return NewHMACSignerFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// NewHMACSignerFromFileAFS returns an `HMACSigner` initialized with the shared secret read from the file
+// supplied with a supplied `afero.Fs`.
+func NewHMACSignerFromFileAFS(afs afero.Fs, filename string) (Signer, error) {
+	secret, err := ReadHMACSecretFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HMACSigner{
+		Secret:    secret,
+		Algorithm: HS256,
+	}, nil
+}
+
+// SignClaims takes a list of claims and produces a signed token.
+func (h *HMACSigner) SignClaims(claims ...Claim) ([]byte, error) {
+	if err := checkHMACSecret(h.Secret); err != nil {
+		return nil, err
+	}
+
+	tokenClaims, err := constructSignerClaims(signerPolicy{
+		issuer:   h.Issuer,
+		keyID:    h.KeyID,
+		clock:    h.Clock,
+		claims:   h.DefaultClaims,
+		lifetime: h.Lifetime,
+	}, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenClaims.HMACSign(h.Algorithm, h.Secret)
+	if err != nil {
+		return token, fmt.Errorf("unable to sign claims: %w", err)
+	}
+
+	return token, nil
+}
+
+// HMACVerifier implements the `Verifier` interface and tests a token signed with a shared secret.
+//
+// Issuer, Issuers, Leeway, Clock, RequiredClaims, MaxLifetime, MaxAge, Schema, Revocation and
+// ReplayStore are applied to the token claims in the same way as the `RSAVerifier`.
+//
+// Algorithms restricts the accepted token algorithms, when empty all HMAC algorithms are accepted.
+type HMACVerifier struct {
+	Secret         []byte
+	Issuer         string
+	Issuers        []string
+	Audiences      []string
+	Leeway         time.Duration
+	Clock          Clock
+	RequiredClaims []string
+	MaxLifetime    time.Duration
+	MaxAge         time.Duration
+	Schema         Schema
+	Revocation     RevocationChecker
+	ReplayStore    ReplayStore
+	Algorithms     []string
+}
+
+// hmacAlgorithms are the algorithms accepted by the `HMACVerifier` when none are specified.
+var hmacAlgorithms = []string{HS256, HS384, HS512}
+
+// NewHMACVerifierFromFile returns an `HMACVerifier` initialized with the shared secret
+// read from the file supplied and an audience for token verification.
+func NewHMACVerifierFromFile(audiences []string, filename string) (Verifier, error) {
+	return NewHMACVerifierFromFileAFS(afero.NewOsFs(), audiences, filename)
+}
+
+// NewHMACVerifierFromFileAFS returns an `HMACVerifier` initialized with the shared secret
+// read from the file supplied with a supplied `afero.Fs` and an audience for token verification.
+func NewHMACVerifierFromFileAFS(afs afero.Fs, audiences []string, filename string) (Verifier, error) {
+	secret, err := ReadHMACSecretFromFileAFS(afs, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HMACVerifier{
+		Audiences: audiences,
+		Secret:    secret,
+	}, nil
+}
+
+// Verify takes the token and checks it's signature against the shared secret,
+// and the audience, notbefore and expires validity.
+func (v *HMACVerifier) Verify(token []byte) (VerifyResult, error) {
+	return v.VerifyWithChecks(token)
+}
+
+// VerifyWithChecks verifies the token in the same way as Verify, running the checks supplied
+// before the token ID is recorded by the ReplayStore.
+func (v *HMACVerifier) VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error) {
+	if _, err := checkAlgorithm(token, v.Algorithms, hmacAlgorithms); err != nil {
+		return VerifyResult{}, err
+	}
+
+	if err := checkHMACSecret(v.Secret); err != nil {
+		return VerifyResult{}, err
+	}
+
+	claims, err := pascaljwt.HMACCheck(token, v.Secret)
+	if err != nil {
+		return VerifyResult{}, checkError(err)
+	}
+
+	return verifyClaims(claims, claimsPolicy{
+		issuers:    acceptedIssuers(v.Issuer, v.Issuers),
+		audiences:  v.Audiences,
+		leeway:     v.Leeway,
+		clock:      v.Clock,
+		required:   v.RequiredClaims,
+		lifetime:   v.MaxLifetime,
+		maxAge:     v.MaxAge,
+		schema:     v.Schema,
+		revocation: v.Revocation,
+		replay:     v.ReplayStore,
+		checks:     checks,
+	})
+}
+
+// ReadHMACSecretFromFile reads a shared secret from a file, trailing line endings are removed.
+func ReadHMACSecretFromFile(filename string) ([]byte, error) {
+	return ReadHMACSecretFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ReadHMACSecretFromFileAFS reads a shared secret from a file with a supplied `afero.Fs`,
+// trailing line endings are removed.
+func ReadHMACSecretFromFileAFS(afs afero.Fs, filename string) ([]byte, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secret: %w", err)
+	}
+
+	secret := bytes.TrimRight(data, "\r\n")
+	if err := checkHMACSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func checkHMACSecret(secret []byte) error {
+	if len(secret) < MinHMACSecretLength {
+		return fmt.Errorf("%w: %d bytes, minimum is %d", ErrHMACSecretTooShort, len(secret), MinHMACSecretLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package jwt_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestHMACSigner_ShouldSucceed(t *testing.T) {
+	signer := createHMACSigner(t)
+	verifier := createHMACVerifier(t)
+
+	nbfTime := time.Now().UTC()
+	expTime := time.Now().Add(time.Hour).UTC()
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "subject", true, nbfTime, expTime)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if len(token) == 0 {
+		t.Error("expected token not to be empty")
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectBool(t, "result.IsOnline", result.IsOnline, true)
+	expectString(t, "result.Subject", result.Subject, "subject")
+	expectStringNotEmpty(t, "result.ID", result.ID)
+	expectStringElement(t, "result.Audience[test-audience]", result.Audience, "test-audience")
+	expectTimeVaguelyEqual(t, "result.NotBefore", result.NotBefore, nbfTime)
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, expTime)
+}
+
+func TestHMACSigner_ShouldSucceed_Algorithms(t *testing.T) {
+	verifier := createHMACVerifier(t)
+
+	for _, alg := range []string{jwt.HS256, jwt.HS384, jwt.HS512} {
+		t.Run(alg, func(t *testing.T) {
+			signer := &jwt.HMACSigner{Secret: []byte(hmacSecret), Algorithm: alg}
+
+			token, err := jwt.Sign(
+				signer,
+				[]string{"test-audience"},
+				"test-subject",
+				false,
+				time.Now(), time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := verifier.Verify(token)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "result.Subject", result.Subject, "test-subject")
+		})
+	}
+}
+
+func TestHMACSigner_ShouldFail_ShortSecret(t *testing.T) {
+	signer := &jwt.HMACSigner{Secret: []byte("too-short"), Algorithm: jwt.HS256}
+
+	token, err := signer.SignClaims(jwt.String(jwt.Subject, "test-subject"))
+	expectErrMatch(t, "jwt.ErrHMACSecretTooShort", err, jwt.ErrHMACSecretTooShort)
+	expectByteStringEmpty(t, "token", token)
+
+	_, err = jwt.NewHMACSignerFromFileAFS(createAfs(), "short.secret")
+	expectErrMatch(t, "jwt.ErrHMACSecretTooShort", err, jwt.ErrHMACSecretTooShort)
+
+	_, err = jwt.NewHMACVerifierFromFileAFS(createAfs(), []string{"test-audience"}, "short.secret")
+	expectErrMatch(t, "jwt.ErrHMACSecretTooShort", err, jwt.ErrHMACSecretTooShort)
+}
+
+func TestHMACVerifier_ShouldFail_WrongSecret(t *testing.T) {
+	signer := createHMACSigner(t)
+	verifier := &jwt.HMACVerifier{
+		Secret:    []byte("this-is-a-different-secret-of-at-least-32-bytes"),
+		Audiences: []string{"test-audience"},
+	}
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "test-subject", false, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err == nil || !strings.Contains(err.Error(), "jwt: signature mismatch") {
+		t.Errorf("expected error message '%v' to contain '%s'", err, "jwt: signature mismatch")
+	}
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
+func TestHMACVerifier_ShouldFail_RSAToken(t *testing.T) {
+	signer := createSigner(t)
+	verifier := createHMACVerifier(t)
+
+	token, err := jwt.Sign(signer, []string{"test-audience"}, "test-subject", false, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrAlgorithmNotAllowed", err, jwt.ErrAlgorithmNotAllowed)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
+func TestHMACVerifier_ShouldFail_StringExpires(t *testing.T) {
+	verifier := &jwt.HMACVerifier{
+		Secret:    []byte(hmacSecret),
+		Audiences: []string{"test-audience"},
+	}
+
+	for _, claim := range []string{jwt.Expires, jwt.NotBefore, jwt.Issued} {
+		t.Run(claim, func(t *testing.T) {
+			header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+			payload := base64.RawURLEncoding.EncodeToString([]byte(
+				`{"aud":"test-audience","sub":"test-subject","` + claim + `":"1"}`,
+			))
+
+			mac := hmac.New(sha256.New, []byte(hmacSecret))
+			mac.Write([]byte(header + "." + payload))
+			token := header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+			_, err := verifier.Verify([]byte(token))
+			expectErrMatch(t, "verifier.Verify()", err, jwt.ErrInvalidClaimType)
+
+			var verr *jwt.VerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected error to be a VerificationError, returned '%v'", err)
+			}
+
+			expectString(t, "VerificationError.Claim", verr.Claim, claim)
+		})
+	}
+}
@@ -0,0 +1,539 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrJWKInvalid is returned when a JSON Web Key is missing required members or the members are malformed.
+var ErrJWKInvalid = errors.New("invalid JSON web key")
+
+// ErrJWKUnsupported is returned when a JSON Web Key has an unsupported key type or curve.
+var ErrJWKUnsupported = errors.New("unsupported JSON web key")
+
+const (
+	// KeyUseSignature is the JWK "use" value for keys used to sign or verify tokens.
+	KeyUseSignature = "sig"
+)
+
+// JWK key types and curves (RFC 7518 and RFC 8037).
+const (
+	jwkTypeRSA = "RSA"
+	jwkTypeEC  = "EC"
+	jwkTypeOKP = "OKP"
+	jwkTypeOct = "oct"
+
+	jwkCurveP256    = "P-256"
+	jwkCurveP384    = "P-384"
+	jwkCurveP521    = "P-521"
+	jwkCurveEd25519 = "Ed25519"
+)
+
+// signatureAlgorithms are the JWK "alg" values of the keys that can be used to sign or verify tokens.
+var signatureAlgorithms = []string{
+	RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512, EdDSA, HS256, HS384, HS512,
+}
+
+// JWK is a JSON Web Key (RFC 7517).
+//
+// Key holds one of `*rsa.PublicKey`, `*rsa.PrivateKey`, `*ecdsa.PublicKey`, `*ecdsa.PrivateKey`,
+// `ed25519.PublicKey`, `ed25519.PrivateKey` or a `[]byte` HMAC secret.
+type JWK struct {
+	Key       interface{}
+	KeyID     string
+	Use       string
+	Algorithm string
+}
+
+// JWKSet is a JSON Web Key Set (RFC 7517).
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwkJSON is the JSON representation of a `JWK`.
+type jwkJSON struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+	D         string `json:"d,omitempty"`
+	P         string `json:"p,omitempty"`
+	Q         string `json:"q,omitempty"`
+	DP        string `json:"dp,omitempty"`
+	DQ        string `json:"dq,omitempty"`
+	QI        string `json:"qi,omitempty"`
+	K         string `json:"k,omitempty"`
+}
+
+// NewJWK returns a `JWK` for the supplied key with the key ID set to the RFC 7638 thumbprint.
+func NewJWK(key interface{}, use, alg string) (JWK, error) {
+	jwk := JWK{
+		Key:       key,
+		Use:       use,
+		Algorithm: alg,
+	}
+
+	kid, err := jwk.Thumbprint()
+	if err != nil {
+		return jwk, err
+	}
+
+	jwk.KeyID = kid
+
+	return jwk, nil
+}
+
+// IsPrivate returns true if the JWK holds a private key or HMAC secret.
+func (k JWK) IsPrivate() bool {
+	switch k.Key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey, []byte:
+		return true
+	default:
+		return false
+	}
+}
+
+// Public returns a copy of the JWK holding only the public key, HMAC secrets have no public
+// key and return `ErrJWKUnsupported`.
+func (k JWK) Public() (JWK, error) {
+	switch key := k.Key.(type) {
+	case *rsa.PrivateKey:
+		k.Key = &key.PublicKey
+	case *ecdsa.PrivateKey:
+		k.Key = &key.PublicKey
+	case ed25519.PrivateKey:
+		publicKey, _ := key.Public().(ed25519.PublicKey)
+		k.Key = publicKey
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return k, fmt.Errorf("%w: %T has no public key", ErrJWKUnsupported, k.Key)
+	}
+
+	return k, nil
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of the key, base64url encoded.
+func (k JWK) Thumbprint() (string, error) {
+	j, err := k.toJSON()
+	if err != nil {
+		return "", err
+	}
+
+	// The required members in lexicographic order (RFC 7638, section 3.2).
+	var members string
+
+	switch j.KeyType {
+	case jwkTypeRSA:
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, j.E, j.KeyType, j.N)
+	case jwkTypeEC:
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, j.Curve, j.KeyType, j.X, j.Y)
+	case jwkTypeOKP:
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, j.Curve, j.KeyType, j.X)
+	case jwkTypeOct:
+		members = fmt.Sprintf(`{"k":%q,"kty":%q}`, j.K, j.KeyType)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// MarshalJSON encodes the JWK as JSON.
+func (k JWK) MarshalJSON() ([]byte, error) {
+	j, err := k.toJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes and validates a JWK from JSON.
+func (k *JWK) UnmarshalJSON(data []byte) error {
+	var j jwkJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("%w: %w", ErrJWKInvalid, err)
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+
+	switch j.KeyType {
+	case jwkTypeRSA:
+		key, err = j.rsaKey()
+	case jwkTypeEC:
+		key, err = j.ecdsaKey()
+	case jwkTypeOKP:
+		key, err = j.ed25519Key()
+	case jwkTypeOct:
+		key, err = decodeJWKBytes(j.K, "k")
+	default:
+		err = fmt.Errorf("%w: key type %q", ErrJWKUnsupported, j.KeyType)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*k = JWK{
+		Key:       key,
+		KeyID:     j.KeyID,
+		Use:       j.Use,
+		Algorithm: j.Algorithm,
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes a JWK Set from JSON, keys with a key type, curve or algorithm that is
+// not supported (such as encryption keys) are ignored as required by RFC 7517 section 5.
+func (s *JWKSet) UnmarshalJSON(data []byte) error {
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("%w: %w", ErrJWKInvalid, err)
+	}
+
+	keys := make([]JWK, 0, len(set.Keys))
+
+	for _, raw := range set.Keys {
+		var k JWK
+		if err := json.Unmarshal(raw, &k); err != nil {
+			if errors.Is(err, ErrJWKUnsupported) {
+				continue
+			}
+
+			return err
+		}
+
+		if k.Algorithm != "" && !containsString(signatureAlgorithms, k.Algorithm) {
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	*s = JWKSet{Keys: keys}
+
+	return nil
+}
+
+// LookupKeyID returns the keys in the set with a matching key ID.
+func (s JWKSet) LookupKeyID(kid string) []JWK {
+	o := []JWK{}
+
+	for _, k := range s.Keys {
+		if k.KeyID == kid {
+			o = append(o, k)
+		}
+	}
+
+	return o
+}
+
+// Public returns a copy of the set holding only public keys, HMAC secrets are removed.
+func (s JWKSet) Public() JWKSet {
+	o := JWKSet{Keys: []JWK{}}
+
+	for _, k := range s.Keys {
+		if p, err := k.Public(); err == nil {
+			o.Keys = append(o.Keys, p)
+		}
+	}
+
+	return o
+}
+
+//nolint:cyclop // a case per supported key type.
+func (k JWK) toJSON() (jwkJSON, error) {
+	j := jwkJSON{
+		KeyID:     k.KeyID,
+		Use:       k.Use,
+		Algorithm: k.Algorithm,
+	}
+
+	switch key := k.Key.(type) {
+	case *rsa.PrivateKey:
+		if len(key.Primes) != 2 { //nolint:mnd // multi-prime keys are not supported by JWK "oth" here.
+			return j, fmt.Errorf("%w: multi-prime RSA key", ErrJWKUnsupported)
+		}
+
+		j.setRSAPrivate(key)
+	case *rsa.PublicKey:
+		j.setRSAPublic(key)
+	case *ecdsa.PrivateKey:
+		if err := j.setECDSAPublic(&key.PublicKey); err != nil {
+			return j, err
+		}
+
+		j.D = base64.RawURLEncoding.EncodeToString(key.D.FillBytes(make([]byte, curveByteSize(key.Curve))))
+	case *ecdsa.PublicKey:
+		if err := j.setECDSAPublic(key); err != nil {
+			return j, err
+		}
+	case ed25519.PrivateKey:
+		publicKey, _ := key.Public().(ed25519.PublicKey)
+		j.KeyType = jwkTypeOKP
+		j.Curve = jwkCurveEd25519
+		j.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		j.D = base64.RawURLEncoding.EncodeToString(key.Seed())
+	case ed25519.PublicKey:
+		j.KeyType = jwkTypeOKP
+		j.Curve = jwkCurveEd25519
+		j.X = base64.RawURLEncoding.EncodeToString(key)
+	case []byte:
+		j.KeyType = jwkTypeOct
+		j.K = base64.RawURLEncoding.EncodeToString(key)
+	default:
+		return j, fmt.Errorf("%w: key type %T", ErrJWKUnsupported, k.Key)
+	}
+
+	return j, nil
+}
+
+func (j *jwkJSON) setRSAPublic(key *rsa.PublicKey) {
+	j.KeyType = jwkTypeRSA
+	j.N = encodeJWKInt(key.N)
+	j.E = encodeJWKInt(big.NewInt(int64(key.E)))
+}
+
+// setRSAPrivate sets the private key members, the CRT values are computed from D, P and Q
+// rather than with `Precompute` so a key that is in use by a signer is never modified.
+func (j *jwkJSON) setRSAPrivate(key *rsa.PrivateKey) {
+	p, q := key.Primes[0], key.Primes[1]
+	one := big.NewInt(1)
+
+	j.setRSAPublic(&key.PublicKey)
+	j.D = encodeJWKInt(key.D)
+	j.P = encodeJWKInt(p)
+	j.Q = encodeJWKInt(q)
+	j.DP = encodeJWKInt(new(big.Int).Mod(key.D, new(big.Int).Sub(p, one)))
+	j.DQ = encodeJWKInt(new(big.Int).Mod(key.D, new(big.Int).Sub(q, one)))
+	j.QI = encodeJWKInt(new(big.Int).ModInverse(q, p))
+}
+
+func (j *jwkJSON) setECDSAPublic(key *ecdsa.PublicKey) error {
+	crv, err := jwkCurveName(key.Curve)
+	if err != nil {
+		return err
+	}
+
+	size := curveByteSize(key.Curve)
+	j.KeyType = jwkTypeEC
+	j.Curve = crv
+	j.X = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size)))
+	j.Y = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+
+	return nil
+}
+
+func (j *jwkJSON) rsaKey() (interface{}, error) {
+	n, err := decodeJWKInt(j.N, "n")
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := decodeJWKInt(j.E, "e")
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("%w: invalid RSA exponent", ErrJWKInvalid)
+	}
+
+	publicKey := &rsa.PublicKey{N: n, E: int(e.Int64())}
+
+	if j.D == "" {
+		return publicKey, nil
+	}
+
+	privateKey := &rsa.PrivateKey{PublicKey: *publicKey}
+
+	if privateKey.D, err = decodeJWKInt(j.D, "d"); err != nil {
+		return nil, err
+	}
+
+	p, err := decodeJWKInt(j.P, "p")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := decodeJWKInt(j.Q, "q")
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey.Primes = []*big.Int{p, q}
+
+	if err := privateKey.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWKInvalid, err)
+	}
+
+	privateKey.Precompute()
+
+	return privateKey, nil
+}
+
+func (j *jwkJSON) ecdsaKey() (interface{}, error) {
+	var (
+		curve elliptic.Curve
+		ec    ecdh.Curve
+	)
+
+	switch j.Curve {
+	case jwkCurveP256:
+		curve, ec = elliptic.P256(), ecdh.P256()
+	case jwkCurveP384:
+		curve, ec = elliptic.P384(), ecdh.P384()
+	case jwkCurveP521:
+		curve, ec = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, fmt.Errorf("%w: curve %q", ErrJWKUnsupported, j.Curve)
+	}
+
+	size := curveByteSize(curve)
+
+	x, err := decodeJWKBytes(j.X, "x")
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := decodeJWKBytes(j.Y, "y")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("%w: invalid coordinate length", ErrJWKInvalid)
+	}
+
+	// validate the point is on the curve.
+	point := append(append([]byte{4}, x...), y...) //nolint:mnd // uncompressed point prefix.
+	if _, err := ec.NewPublicKey(point); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWKInvalid, err)
+	}
+
+	publicKey := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if j.D == "" {
+		return publicKey, nil
+	}
+
+	d, err := decodeJWKBytes(j.D, "d")
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := ec.NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWKInvalid, err)
+	}
+
+	if !bytes.Equal(privateKey.PublicKey().Bytes(), point) {
+		return nil, fmt.Errorf("%w: private key does not match public key", ErrJWKInvalid)
+	}
+
+	return &ecdsa.PrivateKey{PublicKey: *publicKey, D: new(big.Int).SetBytes(d)}, nil
+}
+
+func (j *jwkJSON) ed25519Key() (interface{}, error) {
+	if j.Curve != jwkCurveEd25519 {
+		return nil, fmt.Errorf("%w: curve %q", ErrJWKUnsupported, j.Curve)
+	}
+
+	x, err := decodeJWKBytes(j.X, "x")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(x) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: invalid Ed25519 public key length", ErrJWKInvalid)
+	}
+
+	if j.D == "" {
+		return ed25519.PublicKey(x), nil
+	}
+
+	d, err := decodeJWKBytes(j.D, "d")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: invalid Ed25519 private key length", ErrJWKInvalid)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(d)
+	if !bytes.Equal(privateKey[ed25519.SeedSize:], x) {
+		return nil, fmt.Errorf("%w: private key does not match public key", ErrJWKInvalid)
+	}
+
+	return privateKey, nil
+}
+
+func jwkCurveName(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return jwkCurveP256, nil
+	case elliptic.P384():
+		return jwkCurveP384, nil
+	case elliptic.P521():
+		return jwkCurveP521, nil
+	default:
+		return "", ErrUnsupportedCurve
+	}
+}
+
+func curveByteSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8 //nolint:mnd // bits to bytes.
+}
+
+func encodeJWKInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func decodeJWKInt(s, name string) (*big.Int, error) {
+	b, err := decodeJWKBytes(s, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeJWKBytes(s, name string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: missing %q", ErrJWKInvalid, name)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed %q: %w", ErrJWKInvalid, name, err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,344 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestJWK_Thumbprint_RFC7638(t *testing.T) {
+	data := `{"kty":"RSA","e":"AQAB","alg":"RS256","kid":"2011-04-29","n":"` +
+		"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMs" +
+		"tn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5" +
+		"hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw" +
+		`"}`
+
+	var jwk jwt.JWK
+	if err := json.Unmarshal([]byte(data), &jwk); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "jwk.KeyID", jwk.KeyID, "2011-04-29")
+	expectString(t, "jwk.Algorithm", jwk.Algorithm, jwt.RS256)
+
+	thumbprint, err := jwk.Thumbprint()
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	expectString(t, "jwk.Thumbprint()", thumbprint, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs")
+}
+
+func TestJWK_RoundTrip(t *testing.T) {
+	rsaKey, err := jwt.ParsePrivateKey([]byte(rsaPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	ecKey, err := jwt.ParsePrivateKey([]byte(ecPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	edKey, err := jwt.ParsePrivateKey([]byte(edPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name string
+		key  interface{}
+		kty  string
+	}{
+		{"RSA private", rsaKey, "RSA"},
+		{"RSA public", rsaKey.Public(), "RSA"},
+		{"EC private", ecKey, "EC"},
+		{"EC public", ecKey.Public(), "EC"},
+		{"Ed25519 private", edKey, "OKP"},
+		{"Ed25519 public", edKey.Public(), "OKP"},
+		{"HMAC secret", []byte(hmacSecret), "oct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwk, err := jwt.NewJWK(tt.key, jwt.KeyUseSignature, "")
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+			expectStringNotEmpty(t, "jwk.KeyID", jwk.KeyID)
+
+			data, err := json.Marshal(jwk)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			if !strings.Contains(string(data), `"kty":"`+tt.kty+`"`) {
+				t.Errorf("expected JSON '%s' to contain kty '%s'", data, tt.kty)
+			}
+
+			var decoded jwt.JWK
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			expectString(t, "decoded.KeyID", decoded.KeyID, jwk.KeyID)
+			expectString(t, "decoded.Use", decoded.Use, jwt.KeyUseSignature)
+			expectBool(t, "decoded.IsPrivate()", decoded.IsPrivate(), jwk.IsPrivate())
+
+			thumbprint, err := decoded.Thumbprint()
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "decoded.Thumbprint()", thumbprint, jwk.KeyID)
+
+			if !keysEqual(decoded.Key, tt.key) {
+				t.Errorf("expected decoded key to equal original key")
+			}
+		})
+	}
+}
+
+func TestJWK_DoesNotModifyRSAKey(t *testing.T) {
+	parsed, err := jwt.ParsePrivateKey([]byte(rsaPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	precomputed, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("expected key to be type(*rsa.PrivateKey), received type(%T)", parsed)
+	}
+
+	key := &rsa.PrivateKey{PublicKey: precomputed.PublicKey, D: precomputed.D, Primes: precomputed.Primes}
+
+	data, err := json.Marshal(jwt.JWK{Key: key})
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if key.Precomputed.Dp != nil || key.Precomputed.Dq != nil || key.Precomputed.Qinv != nil {
+		t.Error("expected key to not be precomputed by marshalling")
+	}
+
+	var members map[string]string
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	expectString(t, "dp", members["dp"], encode(precomputed.Precomputed.Dp.Bytes()))
+	expectString(t, "dq", members["dq"], encode(precomputed.Precomputed.Dq.Bytes()))
+	expectString(t, "qi", members["qi"], encode(precomputed.Precomputed.Qinv.Bytes()))
+}
+
+func keysEqual(a, b interface{}) bool {
+	switch k := a.(type) {
+	case *rsa.PrivateKey:
+		return k.Equal(b)
+	case *rsa.PublicKey:
+		return k.Equal(b)
+	case *ecdsa.PrivateKey:
+		return k.Equal(b)
+	case *ecdsa.PublicKey:
+		return k.Equal(b)
+	case ed25519.PrivateKey:
+		return k.Equal(b)
+	case ed25519.PublicKey:
+		return k.Equal(b)
+	case []byte:
+		o, ok := b.([]byte)
+
+		return ok && string(k) == string(o)
+	default:
+		return false
+	}
+}
+
+func TestJWK_Public(t *testing.T) {
+	signer, ok := createSigner(t).(*jwt.RSASigner)
+	if !ok {
+		t.Fatal("expected signer to be *jwt.RSASigner")
+	}
+
+	jwk, err := signer.JWK()
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+	expectBool(t, "jwk.IsPrivate()", jwk.IsPrivate(), true)
+	expectString(t, "jwk.Algorithm", jwk.Algorithm, jwt.RS256)
+
+	public, err := jwk.Public()
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+	expectBool(t, "public.IsPrivate()", public.IsPrivate(), false)
+	expectString(t, "public.KeyID", public.KeyID, jwk.KeyID)
+
+	data, err := json.Marshal(public)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+	if strings.Contains(string(data), `"d":`) {
+		t.Errorf("expected public JWK '%s' not to contain private exponent", data)
+	}
+
+	_, err = jwt.JWK{Key: []byte(hmacSecret)}.Public()
+	expectErrMatch(t, "jwt.ErrJWKUnsupported", err, jwt.ErrJWKUnsupported)
+}
+
+func TestJWKSet_MarshalAndLookup(t *testing.T) {
+	rsaJWK, err := createSigner(t).(*jwt.RSASigner).JWK()
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	ecJWK, err := createECDSASigner(t).(*jwt.ECDSASigner).JWK()
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	set := jwt.JWKSet{Keys: []jwt.JWK{rsaJWK, ecJWK, {Key: []byte(hmacSecret), KeyID: "secret"}}}.Public()
+	if len(set.Keys) != 2 {
+		t.Fatalf("set.Keys: expected length '2', returned '%d'", len(set.Keys))
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	var decoded jwt.JWKSet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	keys := decoded.LookupKeyID(ecJWK.KeyID)
+	if len(keys) != 1 {
+		t.Fatalf("LookupKeyID: expected length '1', returned '%d'", len(keys))
+	}
+	expectString(t, "keys[0].Algorithm", keys[0].Algorithm, jwt.ES256)
+
+	if keys := decoded.LookupKeyID("unknown"); len(keys) != 0 {
+		t.Errorf("LookupKeyID: expected length '0', returned '%d'", len(keys))
+	}
+}
+
+func TestJWK_SignersAndVerifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		signer   jwt.Signer
+		fromJWK  func(jwk jwt.JWK) (jwt.Signer, error)
+		verifier func(audiences []string, jwk jwt.JWK) (jwt.Verifier, error)
+	}{
+		{"RSA", createSigner(t), jwt.NewRSASignerFromJWK, jwt.NewRSAVerifierFromJWK},
+		{"ECDSA", createECDSASigner(t), jwt.NewECDSASignerFromJWK, jwt.NewECDSAVerifierFromJWK},
+		{"EdDSA", createEdDSASigner(t), jwt.NewEdDSASignerFromJWK, jwt.NewEdDSAVerifierFromJWK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwk, err := tt.signer.(interface{ JWK() (jwt.JWK, error) }).JWK()
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			data, err := json.Marshal(jwk)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			var decoded jwt.JWK
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			signer, err := tt.fromJWK(decoded)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			verifier, err := tt.verifier([]string{"test-audience"}, decoded)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			token, err := jwt.Sign(signer, []string{"test-audience"}, "test-subject", false, time.Now(), time.Now().Add(time.Hour))
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := verifier.Verify(token)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "result.Subject", result.Subject, "test-subject")
+
+			publicJWK, err := verifier.(interface{ JWK() (jwt.JWK, error) }).JWK()
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+			expectString(t, "publicJWK.KeyID", publicJWK.KeyID, jwk.KeyID)
+			expectBool(t, "publicJWK.IsPrivate()", publicJWK.IsPrivate(), false)
+		})
+	}
+}
+
+func TestJWKSet_UnmarshalJSON_SkipsUnsupportedKeys(t *testing.T) {
+	set, _ := createKeySet(t)
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	unsupported := `{"kty":"OKP","crv":"X25519","use":"enc","x":"hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo"},` +
+		`{"kty":"XYZ","kid":"unknown-kty"},` +
+		`{"kty":"RSA","alg":"RSA-OAEP","use":"enc","n":"AQAB","e":"AQAB"},`
+	data = []byte(strings.Replace(string(data), `{"keys":[`, `{"keys":[`+unsupported, 1))
+
+	var decoded jwt.JWKSet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if len(decoded.Keys) != len(set.Keys) {
+		t.Fatalf("decoded.Keys: expected '%d' keys, returned '%d'", len(set.Keys), len(decoded.Keys))
+	}
+
+	for i, k := range decoded.Keys {
+		expectString(t, "decoded.Keys[].KeyID", k.KeyID, set.Keys[i].KeyID)
+	}
+
+	err = json.Unmarshal([]byte(`{"keys":[{"kty":"RSA","e":"AQAB"}]}`), &decoded)
+	expectErrMatch(t, "json.Unmarshal()", err, jwt.ErrJWKInvalid)
+}
+
+func TestJWK_ShouldFail_Invalid(t *testing.T) {
+	tests := []struct {
+		name, data string
+		expect     error
+	}{
+		{"unknown kty", `{"kty":"XYZ"}`, jwt.ErrJWKUnsupported},
+		{"RSA missing n", `{"kty":"RSA","e":"AQAB"}`, jwt.ErrJWKInvalid},
+		{"RSA malformed e", `{"kty":"RSA","n":"AQAB","e":"!!"}`, jwt.ErrJWKInvalid},
+		{"EC unknown curve", `{"kty":"EC","crv":"P-192","x":"AQAB","y":"AQAB"}`, jwt.ErrJWKUnsupported},
+		{"EC short coordinates", `{"kty":"EC","crv":"P-256","x":"AQAB","y":"AQAB"}`, jwt.ErrJWKInvalid},
+		{"OKP short key", `{"kty":"OKP","crv":"Ed25519","x":"AQAB"}`, jwt.ErrJWKInvalid},
+		{"oct missing k", `{"kty":"oct"}`, jwt.ErrJWKInvalid},
+		{"not JSON object", `[]`, jwt.ErrJWKInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jwk jwt.JWK
+			err := json.Unmarshal([]byte(tt.data), &jwk)
+			expectErrMatch(t, tt.name, err, tt.expect)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
+)
+
+// ErrKeyNotFound is returned when no key in a `KeySet` matches the key ID of a token.
+var ErrKeyNotFound = errors.New("no key found for token")
+
+// KeySet provides the keys used by a `KeySetVerifier`.
+type KeySet interface {
+	// LookupKeys returns the keys matching the key ID, or all keys when the key ID is empty.
+	LookupKeys(kid string) ([]JWK, error)
+}
+
+// LookupKeys returns the keys in the set matching the key ID, or all keys when the key ID
+// is empty, `ErrKeyNotFound` is returned if there are no matching keys.
+func (s JWKSet) LookupKeys(kid string) ([]JWK, error) {
+	keys := s.Keys
+	if kid != "" {
+		keys = s.LookupKeyID(kid)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+	}
+
+	return keys, nil
+}
+
+// KeySetVerifier implements the `Verifier` interface and tests a token against multiple keys
+// indexed by key ID, the key is selected using the "kid" token header and when the token has
+// no key ID every key in the set is tried.
+//
+// Issuer, Issuers, Leeway, Clock, RequiredClaims, MaxLifetime, MaxAge, Schema, Revocation and
+// ReplayStore are applied to the token claims in the same way as the `RSAVerifier`.
+//
+// Algorithms restricts the accepted token algorithms, when empty all RSA, ECDSA and EdDSA
+// algorithms are accepted, HMAC algorithms must be explicitly allowed. Keys with an algorithm
+// set in the JWK are only used for tokens with the same algorithm.
+type KeySetVerifier struct {
+	Keys           KeySet
+	Issuer         string
+	Issuers        []string
+	Audiences      []string
+	Leeway         time.Duration
+	Clock          Clock
+	RequiredClaims []string
+	MaxLifetime    time.Duration
+	MaxAge         time.Duration
+	Schema         Schema
+	Revocation     RevocationChecker
+	ReplayStore    ReplayStore
+	Algorithms     []string
+}
+
+// keySetAlgorithms are the algorithms accepted by the `KeySetVerifier` when none are specified.
+var keySetAlgorithms = []string{RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512, EdDSA}
+
+// NewKeySetVerifierFromFile returns a `KeySetVerifier` initialized with the JWK Set read from
+// the file supplied and an audience for token verification.
+func NewKeySetVerifierFromFile(audiences []string, filename string) (Verifier, error) {
+	return NewKeySetVerifierFromFileAFS(afero.NewOsFs(), audiences, filename)
+}
+
+// NewKeySetVerifierFromFileAFS returns a `KeySetVerifier` initialized with the JWK Set read from
+// the file supplied with a supplied `afero.Fs` and an audience for token verification.
+func NewKeySetVerifierFromFileAFS(afs afero.Fs, audiences []string, filename string) (Verifier, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key set: %w", err)
+	}
+
+	var keys JWKSet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("unable to parse key set: %w", err)
+	}
+
+	return &KeySetVerifier{
+		Audiences: audiences,
+		Keys:      keys,
+	}, nil
+}
+
+// Verify takes the token and checks it's signature against the keys in the key set,
+// and the audience, notbefore and expires validity.
+func (v *KeySetVerifier) Verify(token []byte) (VerifyResult, error) {
+	return v.VerifyWithChecks(token)
+}
+
+// VerifyWithChecks verifies the token in the same way as Verify, running the checks supplied
+// before the token ID is recorded by the ReplayStore.
+func (v *KeySetVerifier) VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error) {
+	header, err := checkAlgorithm(token, v.Algorithms, keySetAlgorithms)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+
+	keys, err := v.Keys.LookupKeys(header.KeyID)
+	if errors.Is(err, ErrKeyNotFound) {
+		return VerifyResult{}, &VerificationError{Reason: ErrKeyNotFound, Claim: "kid"}
+	} else if err != nil {
+		return VerifyResult{}, err
+	}
+
+	claims, err := checkKeys(token, header.Algorithm, keys)
+	if err != nil {
+		return VerifyResult{}, checkError(err)
+	}
+
+	return verifyClaims(claims, claimsPolicy{
+		issuers:    acceptedIssuers(v.Issuer, v.Issuers),
+		audiences:  v.Audiences,
+		leeway:     v.Leeway,
+		clock:      v.Clock,
+		required:   v.RequiredClaims,
+		lifetime:   v.MaxLifetime,
+		maxAge:     v.MaxAge,
+		schema:     v.Schema,
+		revocation: v.Revocation,
+		replay:     v.ReplayStore,
+		checks:     checks,
+	})
+}
+
+// checkKeys checks the token signature against each key in turn, returning the claims
+// from the first key that matches, keys for another use or algorithm are skipped.
+func checkKeys(token []byte, alg string, keys []JWK) (*pascaljwt.Claims, error) {
+	for _, key := range keys {
+		if key.Use != "" && key.Use != KeyUseSignature {
+			continue
+		}
+
+		if key.Algorithm != "" && key.Algorithm != alg {
+			continue
+		}
+
+		claims, err := checkSignature(token, key.Key)
+
+		var algErr pascaljwt.AlgError
+
+		switch {
+		case err == nil:
+			return claims, nil
+		case errors.Is(err, pascaljwt.ErrSigMiss), errors.As(err, &algErr),
+			errors.Is(err, ErrUnsupportedKeyType), errors.Is(err, ErrHMACSecretTooShort):
+			continue
+		default:
+			return nil, err
+		}
+	}
+
+	return nil, pascaljwt.ErrSigMiss
+}
+
+// checkSignature checks the token signature using the check function for the type of key.
+func checkSignature(token []byte, key interface{}) (*pascaljwt.Claims, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return pascaljwt.RSACheck(token, k)
+	case *rsa.PrivateKey:
+		return pascaljwt.RSACheck(token, &k.PublicKey)
+	case *ecdsa.PublicKey:
+		return pascaljwt.ECDSACheck(token, k)
+	case *ecdsa.PrivateKey:
+		return pascaljwt.ECDSACheck(token, &k.PublicKey)
+	case ed25519.PublicKey:
+		return pascaljwt.EdDSACheck(token, k)
+	case ed25519.PrivateKey:
+		publicKey, _ := k.Public().(ed25519.PublicKey)
+
+		return pascaljwt.EdDSACheck(token, publicKey)
+	case []byte:
+		if len(k) < MinHMACSecretLength {
+			return nil, ErrHMACSecretTooShort
+		}
+
+		return pascaljwt.HMACCheck(token, k)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKeyType, key)
+	}
+}
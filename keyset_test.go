@@ -0,0 +1,244 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+	"github.com/spf13/afero"
+)
+
+func createKeySet(t *testing.T) (jwt.JWKSet, []jwt.Signer) {
+	t.Helper()
+
+	rsaSigner := createSigner(t)
+
+	ecSigner, err := jwt.NewECDSASignerFromFileAFS(createAfs(), "ec-key.pem")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	edSigner, err := jwt.NewEdDSASignerFromFileAFS(createAfs(), "ed-key.pem")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	rsaSigner.(*jwt.RSASigner).KeyID = "rsa-key"
+	ecSigner.(*jwt.ECDSASigner).KeyID = "ec-key"
+	edSigner.(*jwt.EdDSASigner).KeyID = "ed-key"
+
+	signers := []jwt.Signer{rsaSigner, ecSigner, edSigner}
+
+	var set jwt.JWKSet
+
+	for _, signer := range signers {
+		jwk, err := signer.(interface{ JWK() (jwt.JWK, error) }).JWK()
+		if err != nil {
+			t.Fatalf("expected error to be nil, returned '%v'", err)
+		}
+
+		public, err := jwk.Public()
+		if err != nil {
+			t.Fatalf("expected error to be nil, returned '%v'", err)
+		}
+
+		set.Keys = append(set.Keys, public)
+	}
+
+	return set, signers
+}
+
+func TestKeySetVerifier_ShouldSucceed_SelectByKeyID(t *testing.T) {
+	set, signers := createKeySet(t)
+	verifier := &jwt.KeySetVerifier{
+		Keys:      set,
+		Audiences: []string{"test-audience"},
+	}
+
+	for _, signer := range signers {
+		token, err := signer.SignClaims(
+			jwt.String(jwt.Subject, "subject"),
+			jwt.Strings(jwt.Audience, []string{"test-audience"}),
+			jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+		)
+		if err != nil {
+			t.Fatalf("expected error to be nil, returned '%v'", err)
+		}
+
+		result, err := verifier.Verify(token)
+		if err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+
+		expectString(t, "result.Subject", result.Subject, "subject")
+		expectStringNotEmpty(t, "result.KeyID", result.KeyID)
+	}
+}
+
+func TestKeySetVerifier_ShouldSucceed_NoKeyIDTriesAllKeys(t *testing.T) {
+	set, _ := createKeySet(t)
+	verifier := &jwt.KeySetVerifier{
+		Keys:      set,
+		Audiences: []string{"test-audience"},
+	}
+
+	signer := createECDSASigner(t)
+
+	token, err := signer.SignClaims(
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "result.Subject", result.Subject, "subject")
+	expectStringEmpty(t, "result.KeyID", result.KeyID)
+}
+
+func TestKeySetVerifier_ShouldFail_UnknownKeyID(t *testing.T) {
+	set, _ := createKeySet(t)
+	verifier := &jwt.KeySetVerifier{
+		Keys:      set,
+		Audiences: []string{"test-audience"},
+	}
+
+	signer := createSigner(t)
+	signer.(*jwt.RSASigner).KeyID = "retired-key"
+
+	token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrKeyNotFound)
+}
+
+func TestKeySetVerifier_ShouldFail_KeyNotInSet(t *testing.T) {
+	set, _ := createKeySet(t)
+	set.Keys = set.Keys[1:]
+	verifier := &jwt.KeySetVerifier{
+		Keys:      set,
+		Audiences: []string{"test-audience"},
+	}
+
+	token, err := createSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if _, err = verifier.Verify(token); err == nil {
+		t.Error("expected error to not be nil")
+	}
+}
+
+func TestKeySetVerifier_ShouldFail_KeyAlgorithmMismatch(t *testing.T) {
+	set, signers := createKeySet(t)
+	verifier := &jwt.KeySetVerifier{
+		Keys:      set,
+		Audiences: []string{"test-audience"},
+	}
+
+	signer := signers[0].(*jwt.RSASigner)
+	signer.Algorithm = jwt.PS256
+
+	token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if _, err = verifier.Verify(token); err == nil {
+		t.Error("expected error to not be nil")
+	}
+}
+
+func TestKeySetVerifier_ShouldFail_EncryptionKey(t *testing.T) {
+	set, signers := createKeySet(t)
+
+	key := set.Keys[1]
+	key.Use = "enc"
+	key.Algorithm = ""
+
+	verifier := &jwt.KeySetVerifier{
+		Keys:      jwt.JWKSet{Keys: []jwt.JWK{key}},
+		Audiences: []string{"test-audience"},
+	}
+
+	token, err := signers[1].SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrSignatureInvalid)
+
+	verifier.Keys = jwt.JWKSet{Keys: []jwt.JWK{set.Keys[1]}}
+
+	if _, err = verifier.Verify(token); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+}
+
+func TestKeySetVerifier_ShouldFail_HMACNotAllowedByDefault(t *testing.T) {
+	secret, err := jwt.NewJWK([]byte(hmacSecret), jwt.KeyUseSignature, "")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := &jwt.KeySetVerifier{
+		Keys:      jwt.JWKSet{Keys: []jwt.JWK{secret}},
+		Audiences: []string{"test-audience"},
+	}
+
+	token, err := createHMACSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrAlgorithmNotAllowed)
+
+	verifier.Algorithms = []string{jwt.HS256}
+
+	if _, err = verifier.Verify(token); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+}
+
+func TestKeySetVerifier_ShouldSucceed_FromFile(t *testing.T) {
+	set, signers := createKeySet(t)
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	afs := afero.NewMemMapFs()
+	if err := afero.WriteFile(afs, "jwks.json", data, 0o600); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier, err := jwt.NewKeySetVerifierFromFileAFS(afs, []string{"test-audience"}, "jwks.json")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := signers[1].SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "result.KeyID", result.KeyID, "ec-key")
+}
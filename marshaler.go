@@ -0,0 +1,29 @@
+package jwt
+
+// ObjectMarshaler allows user-defined types to add themselves to the token claims as a
+// JSON object, the returned map is serialized with encoding/json.
+type ObjectMarshaler interface {
+	MarshalClaimObject() (map[string]interface{}, error)
+}
+
+// ArrayMarshaler allows user-defined types to add themselves to the token claims as a
+// JSON array, the returned slice is serialized with encoding/json.
+type ArrayMarshaler interface {
+	MarshalClaimArray() ([]interface{}, error)
+}
+
+// ObjectMarshalerFunc is a type adapter that turns a function into an ObjectMarshaler.
+type ObjectMarshalerFunc func() (map[string]interface{}, error)
+
+// MarshalClaimObject calls the underlying function.
+func (f ObjectMarshalerFunc) MarshalClaimObject() (map[string]interface{}, error) {
+	return f()
+}
+
+// ArrayMarshalerFunc is a type adapter that turns a function into an ArrayMarshaler.
+type ArrayMarshalerFunc func() ([]interface{}, error)
+
+// MarshalClaimArray calls the underlying function.
+func (f ArrayMarshalerFunc) MarshalClaimArray() ([]interface{}, error) {
+	return f()
+}
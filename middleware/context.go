@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+// contextKey is the type of the context key for the verify result, unexported to prevent
+// collisions with keys defined in other packages.
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the verify result.
+func NewContext(ctx context.Context, result jwt.VerifyResult) context.Context {
+	return context.WithValue(ctx, contextKey{}, result)
+}
+
+// FromContext returns the verify result stored in the context by the `Middleware`.
+func FromContext(ctx context.Context) (jwt.VerifyResult, bool) {
+	result, ok := ctx.Value(contextKey{}).(jwt.VerifyResult)
+
+	return result, ok
+}
+
+// SubjectFromContext returns the subject of the verified token stored in the context.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	result, ok := FromContext(ctx)
+
+	return result.Subject, ok
+}
+
+// ClaimsFromContext returns the claims of the verified token stored in the context.
+func ClaimsFromContext(ctx context.Context) (map[string]jwt.Claim, bool) {
+	result, ok := FromContext(ctx)
+
+	return result.Claims, ok
+}
@@ -0,0 +1,318 @@
+// Package middleware provides net/http middleware that verifies bearer tokens with a `jwt.Verifier`
+// and stores the `jwt.VerifyResult` in the request context.
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+// ErrInvalidRequest is returned by an `Extractor` when the request carries a token in an invalid
+// format, or in more than one location.
+var ErrInvalidRequest = errors.New("invalid token request")
+
+// errInsufficientScope is returned by the scope check when the token has not been granted the
+// required scopes.
+var errInsufficientScope = errors.New("insufficient scope")
+
+// DefaultQueryParameter is the query parameter defined by RFC 6750 for access tokens.
+const DefaultQueryParameter = "access_token"
+
+// Extractor returns the token from a request, ok is false when the request does not carry a
+// token in the location the extractor checks.
+type Extractor func(r *http.Request) (token string, ok bool, err error)
+
+// AuthorizationHeader returns an `Extractor` for tokens in the Authorization header using the
+// "Bearer" scheme, headers using other schemes are ignored.
+func AuthorizationHeader() Extractor {
+	return func(r *http.Request) (string, bool, error) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			return "", false, nil
+		}
+
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") {
+			return "", false, nil
+		}
+
+		token = strings.TrimSpace(token)
+		if token == "" || strings.ContainsAny(token, " \t") {
+			return "", false, fmt.Errorf("%w: malformed authorization header", ErrInvalidRequest)
+		}
+
+		return token, true, nil
+	}
+}
+
+// Cookie returns an `Extractor` for tokens in the named cookie.
+func Cookie(name string) Extractor {
+	return func(r *http.Request) (string, bool, error) {
+		cookie, err := r.Cookie(name)
+		if err != nil || cookie.Value == "" {
+			return "", false, nil //nolint:nilerr // a missing cookie is not an error.
+		}
+
+		return cookie.Value, true, nil
+	}
+}
+
+// Query returns an `Extractor` for tokens in the named query parameter.
+func Query(name string) Extractor {
+	return func(r *http.Request) (string, bool, error) {
+		values, ok := r.URL.Query()[name]
+		if !ok || len(values) == 0 || values[0] == "" {
+			return "", false, nil
+		}
+
+		if len(values) > 1 {
+			return "", false, fmt.Errorf("%w: multiple %s parameters", ErrInvalidRequest, name)
+		}
+
+		return values[0], true, nil
+	}
+}
+
+// Option configures a `Middleware`.
+type Option func(m *Middleware)
+
+// WithExtractors sets the locations tokens are read from, replacing the default of the
+// Authorization header. A request carrying a token in more than one location is rejected.
+func WithExtractors(extractors ...Extractor) Option {
+	return func(m *Middleware) {
+		m.extractors = extractors
+	}
+}
+
+// WithRealm sets the realm returned in the WWW-Authenticate header.
+func WithRealm(realm string) Option {
+	return func(m *Middleware) {
+		m.realm = realm
+	}
+}
+
+// WithRequiredScopes rejects tokens that have not been granted all of the scopes supplied, the
+// scopes are read from the "scope" and "scp" claims.
+//
+// When the verifier is a `jwt.CheckVerifier` the scopes are checked before the token ID is
+// recorded by a `jwt.ReplayStore`, other verifiers record the token ID before the scopes are
+// checked so a token rejected for its scopes can not be used again.
+func WithRequiredScopes(scopes ...string) Option {
+	return func(m *Middleware) {
+		m.scopes = append(m.scopes, scopes...)
+	}
+}
+
+// Middleware verifies the token in each request, storing the `jwt.VerifyResult` in the request
+// context before calling the next handler.
+//
+// Requests without a token, or with a token that fails verification, are rejected with the
+// WWW-Authenticate challenge and status code defined by RFC 6750. Tokens without the required
+// scopes are rejected with the "insufficient_scope" error.
+type Middleware struct {
+	verifier   jwt.Verifier
+	extractors []Extractor
+	realm      string
+	scopes     []string
+}
+
+// New returns a `Middleware` that verifies tokens with the verifier supplied.
+func New(verifier jwt.Verifier, opts ...Option) *Middleware {
+	m := &Middleware{
+		verifier:   verifier,
+		extractors: []Extractor{AuthorizationHeader()},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Handler returns a `http.Handler` that calls next when the request token is valid.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return m.handler(next, m.scopes)
+}
+
+// HandlerFunc returns a `http.Handler` that calls next when the request token is valid.
+func (m *Middleware) HandlerFunc(next http.HandlerFunc) http.Handler {
+	return m.Handler(next)
+}
+
+// RequireScopes returns middleware for a single route that also requires the token to have been
+// granted all of the scopes supplied, in addition to any from `WithRequiredScopes`. The scopes are
+// checked in the same way as `WithRequiredScopes`.
+func (m *Middleware) RequireScopes(scopes ...string) func(next http.Handler) http.Handler {
+	required := append(append([]string{}, m.scopes...), scopes...)
+
+	return func(next http.Handler) http.Handler {
+		return m.handler(next, required)
+	}
+}
+
+// handler returns a `http.Handler` that calls next when the request token is valid and has been
+// granted all of the required scopes.
+func (m *Middleware) handler(next http.Handler, scopes []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := m.extract(r)
+		if err != nil {
+			m.challenge(w, http.StatusBadRequest, "invalid_request", "The access token is malformed or duplicated")
+
+			return
+		}
+
+		if token == "" {
+			m.challenge(w, http.StatusUnauthorized, "", "")
+
+			return
+		}
+
+		result, err := m.verify([]byte(token), scopes)
+		if errors.Is(err, errInsufficientScope) {
+			m.challenge(w, http.StatusForbidden, "insufficient_scope",
+				"The access token has not been granted the required scope", "scope="+quote(strings.Join(scopes, " ")))
+
+			return
+		}
+
+		if err != nil {
+			m.verificationError(w, err)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), result)))
+	})
+}
+
+// verify verifies the token and checks it has been granted all of the required scopes, before
+// the token ID is recorded when the verifier supports checks.
+func (m *Middleware) verify(token []byte, scopes []string) (jwt.VerifyResult, error) {
+	check := func(result jwt.VerifyResult) error {
+		if len(scopes) > 0 && !result.HasAllScopes(scopes...) {
+			return errInsufficientScope
+		}
+
+		return nil
+	}
+
+	if verifier, ok := m.verifier.(jwt.CheckVerifier); ok {
+		return verifier.VerifyWithChecks(token, check)
+	}
+
+	result, err := m.verifier.Verify(token)
+	if err != nil {
+		return result, err
+	}
+
+	return result, check(result)
+}
+
+// extract returns the token from the request, or an empty string when there is none.
+func (m *Middleware) extract(r *http.Request) (string, error) {
+	found := ""
+
+	for _, extractor := range m.extractors {
+		token, ok, err := extractor(r)
+		if err != nil {
+			return "", err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if found != "" {
+			return "", fmt.Errorf("%w: token in more than one location", ErrInvalidRequest)
+		}
+
+		found = token
+	}
+
+	return found, nil
+}
+
+// verificationError writes the response for a token that failed verification, errors that are
+// not caused by the token (such as an unavailable key set or replay store) are server errors.
+func (m *Middleware) verificationError(w http.ResponseWriter, err error) {
+	var verr *jwt.VerificationError
+
+	switch {
+	case errors.As(err, &verr),
+		errors.Is(err, jwt.ErrMalformedToken),
+		errors.Is(err, jwt.ErrInvalidClaimType),
+		errors.Is(err, jwt.ErrClaimFormatInvalid):
+		m.challenge(w, http.StatusUnauthorized, "invalid_token", errorDescription(err))
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// challenge writes the RFC 6750 WWW-Authenticate header and status code.
+func (m *Middleware) challenge(w http.ResponseWriter, status int, code, description string, params ...string) {
+	attrs := []string{}
+
+	if m.realm != "" {
+		attrs = append(attrs, "realm="+quote(m.realm))
+	}
+
+	if code != "" {
+		attrs = append(attrs, "error="+quote(code))
+	}
+
+	if description != "" {
+		attrs = append(attrs, "error_description="+quote(description))
+	}
+
+	attrs = append(attrs, params...)
+
+	challenge := "Bearer"
+	if len(attrs) > 0 {
+		challenge += " " + strings.Join(attrs, ", ")
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(status), status)
+}
+
+// errorDescription returns a human readable description of the verification error.
+//
+//nolint:cyclop // a case per error.
+func errorDescription(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "The access token expired"
+	case errors.Is(err, jwt.ErrTokenNotYetValid):
+		return "The access token is not yet valid"
+	case errors.Is(err, jwt.ErrSignatureInvalid), errors.Is(err, jwt.ErrKeyNotFound):
+		return "The access token signature is invalid"
+	case errors.Is(err, jwt.ErrAlgorithmNotAllowed):
+		return "The access token algorithm is not allowed"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "The access token audience is invalid"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "The access token issuer is invalid"
+	case errors.Is(err, jwt.ErrTokenMissingClaim):
+		return "The access token is missing a required claim"
+	case errors.Is(err, jwt.ErrTokenLifetimeExceeded), errors.Is(err, jwt.ErrTokenTooOld):
+		return "The access token lifetime is too long"
+	case errors.Is(err, jwt.ErrTokenRevoked):
+		return "The access token has been revoked"
+	case errors.Is(err, jwt.ErrTokenReplayed):
+		return "The access token has already been used"
+	case errors.Is(err, jwt.ErrMalformedToken):
+		return "The access token is malformed"
+	default:
+		return "The access token is invalid"
+	}
+}
+
+// quote returns the value as a quoted-string for an auth-param.
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
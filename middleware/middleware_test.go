@@ -0,0 +1,271 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+	"github.com/na4ma4/jwt/v2/middleware"
+)
+
+//nolint:gochecknoglobals // test secret.
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func createToken(t *testing.T, claims ...jwt.Claim) string {
+	t.Helper()
+
+	signer, err := jwt.NewSigner(jwt.WithKey(testSecret), jwt.WithIssuer("test-issuer"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := signer.SignClaims(append([]jwt.Claim{
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.String(jwt.Subject, "test-subject"),
+		jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+	}, claims...)...)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return string(token)
+}
+
+func createVerifier(t *testing.T) jwt.Verifier {
+	t.Helper()
+
+	verifier, err := jwt.NewVerifier(jwt.WithKey(testSecret), jwt.WithAudiences("test-audience"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return verifier
+}
+
+// subjectHandler writes the subject from the request context.
+func subjectHandler(w http.ResponseWriter, r *http.Request) {
+	subject, ok := middleware.SubjectFromContext(r.Context())
+	if !ok {
+		http.Error(w, "missing result", http.StatusInternalServerError)
+
+		return
+	}
+
+	_, _ = w.Write([]byte(subject))
+}
+
+func serve(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func expectResponse(t *testing.T, w *httptest.ResponseRecorder, status int, challenge string) {
+	t.Helper()
+
+	if w.Code != status {
+		t.Errorf("status code: expected '%d', returned '%d'", status, w.Code)
+	}
+
+	if v := w.Header().Get("WWW-Authenticate"); v != challenge {
+		t.Errorf("WWW-Authenticate: expected '%s', returned '%s'", challenge, v)
+	}
+}
+
+func TestMiddleware_AuthorizationHeader(t *testing.T) {
+	handler := middleware.New(createVerifier(t), middleware.WithRealm("test")).HandlerFunc(subjectHandler)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+createToken(t))
+
+	w := serve(handler, r)
+	expectResponse(t, w, http.StatusOK, "")
+
+	if w.Body.String() != "test-subject" {
+		t.Errorf("body: expected '%s', returned '%s'", "test-subject", w.Body.String())
+	}
+}
+
+func TestMiddleware_Extractors(t *testing.T) {
+	handler := middleware.New(
+		createVerifier(t),
+		middleware.WithExtractors(
+			middleware.AuthorizationHeader(),
+			middleware.Cookie("token"),
+			middleware.Query(middleware.DefaultQueryParameter),
+		),
+	).HandlerFunc(subjectHandler)
+
+	token := createToken(t)
+
+	cookie := httptest.NewRequest(http.MethodGet, "/", nil)
+	cookie.AddCookie(&http.Cookie{Name: "token", Value: token})
+	expectResponse(t, serve(handler, cookie), http.StatusOK, "")
+
+	query := httptest.NewRequest(http.MethodGet, "/?access_token="+token, nil)
+	expectResponse(t, serve(handler, query), http.StatusOK, "")
+
+	both := httptest.NewRequest(http.MethodGet, "/?access_token="+token, nil)
+	both.Header.Set("Authorization", "Bearer "+token)
+	expectResponse(t, serve(handler, both), http.StatusBadRequest,
+		`Bearer error="invalid_request", error_description="The access token is malformed or duplicated"`)
+
+	onlyHeader := middleware.New(createVerifier(t)).HandlerFunc(subjectHandler)
+	expectResponse(t, serve(onlyHeader, httptest.NewRequest(http.MethodGet, "/?access_token="+token, nil)),
+		http.StatusUnauthorized, "Bearer")
+}
+
+func TestMiddleware_Errors(t *testing.T) {
+	expired := createToken(t, jwt.Time(jwt.Expires, time.Now().Add(-time.Hour)))
+	audience := createToken(t, jwt.Strings(jwt.Audience, []string{"other-audience"}))
+
+	tests := []struct {
+		name      string
+		header    string
+		status    int
+		challenge string
+	}{
+		{"missing", "", http.StatusUnauthorized, `Bearer realm="test"`},
+		{"other scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, `Bearer realm="test"`},
+		{
+			"empty bearer", "Bearer ", http.StatusBadRequest,
+			`Bearer realm="test", error="invalid_request", error_description="The access token is malformed or duplicated"`,
+		},
+		{
+			"malformed", "Bearer garbage", http.StatusUnauthorized,
+			`Bearer realm="test", error="invalid_token", error_description="The access token is malformed"`,
+		},
+		{
+			"expired", "Bearer " + expired, http.StatusUnauthorized,
+			`Bearer realm="test", error="invalid_token", error_description="The access token expired"`,
+		},
+		{
+			"audience", "bearer " + audience, http.StatusUnauthorized,
+			`Bearer realm="test", error="invalid_token", error_description="The access token audience is invalid"`,
+		},
+	}
+
+	handler := middleware.New(createVerifier(t), middleware.WithRealm("test")).HandlerFunc(subjectHandler)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			expectResponse(t, serve(handler, r), tt.status, tt.challenge)
+		})
+	}
+}
+
+type failingReplayStore struct{}
+
+func (failingReplayStore) Record(string, time.Time) error {
+	return errors.New("store unavailable")
+}
+
+func TestMiddleware_ServerError(t *testing.T) {
+	verifier, err := jwt.NewVerifier(
+		jwt.WithKey(testSecret),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithReplayStore(failingReplayStore{}),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+createToken(t))
+
+	expectResponse(t, serve(middleware.New(verifier).HandlerFunc(subjectHandler), r), http.StatusInternalServerError, "")
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := middleware.FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Errorf("middleware.FromContext(): expected 'false', returned 'true'")
+	}
+
+	ctx := middleware.NewContext(httptest.NewRequest(http.MethodGet, "/", nil).Context(), jwt.VerifyResult{
+		Subject: "subject",
+		Claims:  map[string]jwt.Claim{"name": jwt.String("name", "value")},
+	})
+
+	if subject, ok := middleware.SubjectFromContext(ctx); !ok || subject != "subject" {
+		t.Errorf("middleware.SubjectFromContext(): returned '%s', '%v'", subject, ok)
+	}
+
+	if claims, ok := middleware.ClaimsFromContext(ctx); !ok || claims["name"].String != "value" {
+		t.Errorf("middleware.ClaimsFromContext(): returned '%v', '%v'", claims, ok)
+	}
+}
+
+func TestMiddleware_RequiredScopes(t *testing.T) {
+	m := middleware.New(createVerifier(t), middleware.WithRealm("test"), middleware.WithRequiredScopes("read"))
+	token := createToken(t, jwt.String(jwt.ScopeClaim, "read write"))
+
+	tests := []struct {
+		name      string
+		handler   http.Handler
+		status    int
+		challenge string
+	}{
+		{"option", m.HandlerFunc(subjectHandler), http.StatusOK, ""},
+		{"route", m.RequireScopes("write")(http.HandlerFunc(subjectHandler)), http.StatusOK, ""},
+		{
+			"route missing scope", m.RequireScopes("admin")(http.HandlerFunc(subjectHandler)), http.StatusForbidden,
+			`Bearer realm="test", error="insufficient_scope", ` +
+				`error_description="The access token has not been granted the required scope", scope="read admin"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			expectResponse(t, serve(tt.handler, r), tt.status, tt.challenge)
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+createToken(t))
+
+	expectResponse(t, serve(m.HandlerFunc(subjectHandler), r), http.StatusForbidden,
+		`Bearer realm="test", error="insufficient_scope", `+
+			`error_description="The access token has not been granted the required scope", scope="read"`)
+}
+
+func TestMiddleware_RequiredScopes_ReplayStore(t *testing.T) {
+	verifier, err := jwt.NewVerifier(
+		jwt.WithKey(testSecret),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithReplayStore(jwt.NewMemoryReplayStore()),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	m := middleware.New(verifier)
+	token := createToken(t, jwt.String(jwt.ScopeClaim, "read"))
+
+	request := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+
+		return r
+	}
+
+	expectResponse(t, serve(m.RequireScopes("admin")(http.HandlerFunc(subjectHandler)), request()), http.StatusForbidden,
+		`Bearer error="insufficient_scope", `+
+			`error_description="The access token has not been granted the required scope", scope="admin"`)
+
+	expectResponse(t, serve(m.RequireScopes("read")(http.HandlerFunc(subjectHandler)), request()), http.StatusOK, "")
+
+	expectResponse(t, serve(m.RequireScopes("read")(http.HandlerFunc(subjectHandler)), request()), http.StatusUnauthorized,
+		`Bearer error="invalid_token", error_description="The access token has already been used"`)
+}
@@ -0,0 +1,665 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// ErrInvalidOption is returned by `NewSigner` and `NewVerifier` when the options supplied are
+// invalid or incomplete.
+var ErrInvalidOption = errors.New("invalid option")
+
+// config is the configuration built from the options supplied to `NewSigner` and `NewVerifier`.
+type config struct {
+	afs            afero.Fs
+	keySources     int
+	keyFile        string
+	secretFile     string
+	keyData        []byte
+	key            interface{}
+	keySet         KeySet
+	jwk            *JWK
+	passphrase     PassphraseFunc
+	algorithms     []string
+	issuers        []string
+	audiences      []string
+	keyID          string
+	clock          Clock
+	leeway         time.Duration
+	claims         []Claim
+	lifetime       time.Duration
+	requiredClaims []string
+	maxLifetime    time.Duration
+	maxAge         time.Duration
+	schema         Schema
+	revocation     RevocationChecker
+	replayStore    ReplayStore
+}
+
+// SignerOption configures the signer returned by `NewSigner`.
+type SignerOption interface {
+	applySigner(c *config) error
+}
+
+// VerifierOption configures the verifier returned by `NewVerifier`.
+type VerifierOption interface {
+	applyVerifier(c *config) error
+}
+
+// Option configures both signers and verifiers.
+type Option func(c *config) error
+
+func (o Option) applySigner(c *config) error   { return o(c) }
+func (o Option) applyVerifier(c *config) error { return o(c) }
+
+type signerOption func(c *config) error
+
+func (o signerOption) applySigner(c *config) error { return o(c) }
+
+type verifierOption func(c *config) error
+
+func (o verifierOption) applyVerifier(c *config) error { return o(c) }
+
+// WithAFS sets the `afero.Fs` used to read key files, the OS filesystem is used by default.
+func WithAFS(afs afero.Fs) Option {
+	return func(c *config) error {
+		if afs == nil {
+			return fmt.Errorf("%w: nil afero.Fs", ErrInvalidOption)
+		}
+
+		c.afs = afs
+
+		return nil
+	}
+}
+
+// WithKeyFile reads a PEM encoded key from the file supplied, signers require a private key
+// and verifiers accept a certificate, public key or private key.
+func WithKeyFile(filename string) Option {
+	return func(c *config) error {
+		c.keySources++
+		c.keyFile = filename
+
+		return nil
+	}
+}
+
+// WithKeyBytes parses a PEM encoded key from the data supplied, signers require a private key
+// and verifiers accept a certificate, public key or private key.
+func WithKeyBytes(data []byte) Option {
+	return func(c *config) error {
+		c.keySources++
+		c.keyData = data
+
+		return nil
+	}
+}
+
+// WithKey uses the key supplied, either an RSA, ECDSA or Ed25519 key, a []byte HMAC shared
+// secret, or a JWK whose key ID and algorithm are used unless set by other options.
+func WithKey(key interface{}) Option {
+	return func(c *config) error {
+		c.keySources++
+
+		if jwk, ok := key.(JWK); ok {
+			c.jwk = &jwk
+			key = jwk.Key
+		}
+
+		c.key = key
+
+		return nil
+	}
+}
+
+// WithSecretFile reads a HMAC shared secret from the file supplied.
+func WithSecretFile(filename string) Option {
+	return func(c *config) error {
+		c.keySources++
+		c.secretFile = filename
+
+		return nil
+	}
+}
+
+// WithPassphrase sets the passphrase used to decrypt an encrypted private key file or data.
+func WithPassphrase(passphrase PassphraseFunc) SignerOption {
+	return signerOption(func(c *config) error {
+		c.passphrase = passphrase
+
+		return nil
+	})
+}
+
+// WithKeySet verifies tokens against the keys in the `KeySet` supplied.
+func WithKeySet(keySet KeySet) VerifierOption {
+	return verifierOption(func(c *config) error {
+		c.keySources++
+		c.keySet = keySet
+
+		return nil
+	})
+}
+
+// WithAlgorithm sets the signing algorithm for signers, for verifiers the algorithm is added to
+// the allowed algorithms.
+func WithAlgorithm(alg string) Option {
+	return func(c *config) error {
+		c.algorithms = append(c.algorithms, alg)
+
+		return nil
+	}
+}
+
+// WithIssuer sets the issuer for signers, for verifiers the issuer is added to the accepted issuers.
+func WithIssuer(issuer string) Option {
+	return func(c *config) error {
+		c.issuers = append(c.issuers, issuer)
+
+		return nil
+	}
+}
+
+// WithKeyID sets the key ID stamped in the token header by signers.
+func WithKeyID(kid string) SignerOption {
+	return signerOption(func(c *config) error {
+		c.keyID = kid
+
+		return nil
+	})
+}
+
+// WithClock sets the `Clock` used for the current time.
+func WithClock(clock Clock) Option {
+	return func(c *config) error {
+		c.clock = clock
+
+		return nil
+	}
+}
+
+// WithDefaultClaims sets the claims added to every token by signers.
+func WithDefaultClaims(claims ...Claim) SignerOption {
+	return signerOption(func(c *config) error {
+		c.claims = append(c.claims, claims...)
+
+		return nil
+	})
+}
+
+// WithLifetime sets the lifetime of tokens created by signers, the issued, notbefore and
+// expires claims are stamped on every token unless supplied when signing.
+func WithLifetime(lifetime time.Duration) SignerOption {
+	return signerOption(func(c *config) error {
+		if lifetime <= 0 {
+			return fmt.Errorf("%w: lifetime must be positive", ErrInvalidOption)
+		}
+
+		c.lifetime = lifetime
+
+		return nil
+	})
+}
+
+// WithAudiences adds to the audiences accepted by verifiers.
+func WithAudiences(audiences ...string) VerifierOption {
+	return verifierOption(func(c *config) error {
+		c.audiences = append(c.audiences, audiences...)
+
+		return nil
+	})
+}
+
+// WithLeeway sets the clock skew tolerated by verifiers.
+func WithLeeway(leeway time.Duration) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if leeway < 0 {
+			return fmt.Errorf("%w: negative leeway", ErrInvalidOption)
+		}
+
+		c.leeway = leeway
+
+		return nil
+	})
+}
+
+// WithRequiredClaims adds to the claims verifiers require to be present.
+func WithRequiredClaims(claims ...string) VerifierOption {
+	return verifierOption(func(c *config) error {
+		c.requiredClaims = append(c.requiredClaims, claims...)
+
+		return nil
+	})
+}
+
+// WithMaxLifetime sets the maximum token lifetime accepted by verifiers.
+func WithMaxLifetime(lifetime time.Duration) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if lifetime <= 0 {
+			return fmt.Errorf("%w: maximum lifetime must be positive", ErrInvalidOption)
+		}
+
+		c.maxLifetime = lifetime
+
+		return nil
+	})
+}
+
+// WithMaxAge sets the maximum time since a token was issued accepted by verifiers.
+func WithMaxAge(age time.Duration) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if age <= 0 {
+			return fmt.Errorf("%w: maximum age must be positive", ErrInvalidOption)
+		}
+
+		c.maxAge = age
+
+		return nil
+	})
+}
+
+// WithSchema adds claim type hints used by verifiers to decode custom claims, later hints for
+// the same claim replace earlier ones.
+func WithSchema(schema Schema) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if c.schema == nil {
+			c.schema = Schema{}
+		}
+
+		for k, v := range schema {
+			c.schema[k] = v
+		}
+
+		return nil
+	})
+}
+
+// WithRevocationChecker rejects tokens reported as revoked by the `RevocationChecker`.
+func WithRevocationChecker(checker RevocationChecker) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if checker == nil {
+			return fmt.Errorf("%w: revocation checker must not be nil", ErrInvalidOption)
+		}
+
+		c.revocation = checker
+
+		return nil
+	})
+}
+
+// WithReplayStore rejects tokens whose "jti" has already been recorded in the `ReplayStore`.
+func WithReplayStore(store ReplayStore) VerifierOption {
+	return verifierOption(func(c *config) error {
+		if store == nil {
+			return fmt.Errorf("%w: replay store must not be nil", ErrInvalidOption)
+		}
+
+		c.replayStore = store
+
+		return nil
+	})
+}
+
+// NewSigner returns a `Signer` for the type of key supplied in the options, the algorithm is
+// checked against the key type and defaults to RS256, HS256 or the algorithm for the ECDSA curve.
+func NewSigner(opts ...SignerOption) (Signer, error) {
+	c := &config{afs: afero.NewOsFs()}
+
+	for _, opt := range opts {
+		if err := opt.applySigner(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.issuers) > 1 {
+		return nil, fmt.Errorf("%w: signer can only have one issuer", ErrInvalidOption)
+	}
+
+	if len(c.algorithms) > 1 {
+		return nil, fmt.Errorf("%w: signer can only have one algorithm", ErrInvalidOption)
+	}
+
+	c.applyJWK()
+
+	key, err := c.loadKey(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newSigner(key)
+}
+
+// NewVerifier returns a `Verifier` for the type of key supplied in the options, the allowed
+// algorithms are checked against the key type and at least one audience must be supplied.
+func NewVerifier(opts ...VerifierOption) (Verifier, error) {
+	c := &config{afs: afero.NewOsFs()}
+
+	for _, opt := range opts {
+		if err := opt.applyVerifier(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.audiences) == 0 {
+		return nil, fmt.Errorf("%w: no audiences", ErrInvalidOption)
+	}
+
+	if c.keySet != nil {
+		if c.keySources > 1 {
+			return nil, fmt.Errorf("%w: multiple key sources", ErrInvalidOption)
+		}
+
+		return &KeySetVerifier{
+			Keys:           c.keySet,
+			Issuers:        c.issuers,
+			Audiences:      c.audiences,
+			Leeway:         c.leeway,
+			Clock:          c.clock,
+			RequiredClaims: c.requiredClaims,
+			MaxLifetime:    c.maxLifetime,
+			MaxAge:         c.maxAge,
+			Schema:         c.schema,
+			Revocation:     c.revocation,
+			ReplayStore:    c.replayStore,
+			Algorithms:     c.algorithms,
+		}, nil
+	}
+
+	c.applyJWK()
+
+	key, err := c.loadKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newVerifier(key)
+}
+
+// applyJWK sets the key ID and algorithm from a JWK key source when they are not already set.
+func (c *config) applyJWK() {
+	if c.jwk == nil {
+		return
+	}
+
+	if c.keyID == "" {
+		c.keyID = c.jwk.KeyID
+	}
+
+	if len(c.algorithms) == 0 && c.jwk.Algorithm != "" {
+		c.algorithms = []string{c.jwk.Algorithm}
+	}
+}
+
+// loadKey returns the key from the single key source in the options.
+func (c *config) loadKey(private bool) (interface{}, error) {
+	switch {
+	case c.keySources == 0:
+		return nil, fmt.Errorf("%w: no key source", ErrInvalidOption)
+	case c.keySources > 1:
+		return nil, fmt.Errorf("%w: multiple key sources", ErrInvalidOption)
+	case c.key != nil:
+		return c.key, nil
+	case c.secretFile != "":
+		return ReadHMACSecretFromFileAFS(c.afs, c.secretFile)
+	case c.keyFile != "":
+		data, err := afero.ReadFile(c.afs, c.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read key: %w", err)
+		}
+
+		return c.parseKey(data, private)
+	default:
+		return c.parseKey(c.keyData, private)
+	}
+}
+
+// parseKey parses a PEM encoded private key for signers, or a public key falling back to a
+// private key for verifiers.
+func (c *config) parseKey(data []byte, private bool) (interface{}, error) {
+	if c.passphrase != nil {
+		return ParseEncryptedPrivateKey(data, c.passphrase)
+	}
+
+	if private {
+		return ParsePrivateKey(data)
+	}
+
+	if publicKey, err := ParsePublicKey(data); err == nil {
+		return publicKey, nil
+	}
+
+	privateKey, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return privateKey.Public(), nil
+}
+
+// algorithm returns the configured algorithm, or the default if none is configured, checking
+// it is one of the algorithms valid for the key.
+func (c *config) algorithm(valid []string) (string, error) {
+	if len(c.algorithms) == 0 {
+		return valid[0], nil
+	}
+
+	if err := checkKeyAlgorithms(c.algorithms, valid); err != nil {
+		return "", err
+	}
+
+	return c.algorithms[0], nil
+}
+
+// checkKeyAlgorithms returns an error if any of the algorithms is not valid for the key.
+func checkKeyAlgorithms(algorithms, valid []string) error {
+	for _, alg := range algorithms {
+		if !containsString(valid, alg) {
+			return fmt.Errorf("%w: algorithm %q is not valid for the key", ErrInvalidOption, alg)
+		}
+	}
+
+	return nil
+}
+
+// containsString returns true if the value is in the slice.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signerIssuer returns the single issuer for a signer.
+func (c *config) signerIssuer() string {
+	if len(c.issuers) == 0 {
+		return ""
+	}
+
+	return c.issuers[0]
+}
+
+// newSigner returns the signer for the type of key.
+//
+//nolint:cyclop // a case per supported key type.
+func (c *config) newSigner(key interface{}) (Signer, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg, err := c.algorithm(rsaAlgorithms)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RSASigner{
+			PrivateKey:    k,
+			Issuer:        c.signerIssuer(),
+			Algorithm:     alg,
+			KeyID:         c.keyID,
+			Clock:         c.clock,
+			DefaultClaims: c.claims,
+			Lifetime:      c.lifetime,
+		}, nil
+	case *ecdsa.PrivateKey:
+		curveAlg, err := ECDSAAlgorithm(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+
+		alg, err := c.algorithm([]string{curveAlg})
+		if err != nil {
+			return nil, err
+		}
+
+		return &ECDSASigner{
+			PrivateKey:    k,
+			Issuer:        c.signerIssuer(),
+			Algorithm:     alg,
+			KeyID:         c.keyID,
+			Clock:         c.clock,
+			DefaultClaims: c.claims,
+			Lifetime:      c.lifetime,
+		}, nil
+	case ed25519.PrivateKey:
+		if _, err := c.algorithm(eddsaAlgorithms); err != nil {
+			return nil, err
+		}
+
+		return &EdDSASigner{
+			PrivateKey:    k,
+			Issuer:        c.signerIssuer(),
+			KeyID:         c.keyID,
+			Clock:         c.clock,
+			DefaultClaims: c.claims,
+			Lifetime:      c.lifetime,
+		}, nil
+	case []byte:
+		if err := checkHMACSecret(k); err != nil {
+			return nil, err
+		}
+
+		alg, err := c.algorithm(hmacAlgorithms)
+		if err != nil {
+			return nil, err
+		}
+
+		return &HMACSigner{
+			Secret:        k,
+			Issuer:        c.signerIssuer(),
+			Algorithm:     alg,
+			KeyID:         c.keyID,
+			Clock:         c.clock,
+			DefaultClaims: c.claims,
+			Lifetime:      c.lifetime,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T is not a private key", ErrUnsupportedKeyType, key)
+	}
+}
+
+// newVerifier returns the verifier for the type of key.
+//
+//nolint:cyclop,funlen // a case per supported key type.
+func (c *config) newVerifier(key interface{}) (Verifier, error) {
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if err := checkKeyAlgorithms(c.algorithms, rsaAlgorithms); err != nil {
+			return nil, err
+		}
+
+		return &RSAVerifier{
+			PublicKey:      k,
+			Issuers:        c.issuers,
+			Audiences:      c.audiences,
+			Leeway:         c.leeway,
+			Clock:          c.clock,
+			RequiredClaims: c.requiredClaims,
+			MaxLifetime:    c.maxLifetime,
+			MaxAge:         c.maxAge,
+			Schema:         c.schema,
+			Revocation:     c.revocation,
+			ReplayStore:    c.replayStore,
+			Algorithms:     c.algorithms,
+		}, nil
+	case *ecdsa.PublicKey:
+		curveAlg, err := ECDSAAlgorithm(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkKeyAlgorithms(c.algorithms, []string{curveAlg}); err != nil {
+			return nil, err
+		}
+
+		return &ECDSAVerifier{
+			PublicKey:      k,
+			Issuers:        c.issuers,
+			Audiences:      c.audiences,
+			Leeway:         c.leeway,
+			Clock:          c.clock,
+			RequiredClaims: c.requiredClaims,
+			MaxLifetime:    c.maxLifetime,
+			MaxAge:         c.maxAge,
+			Schema:         c.schema,
+			Revocation:     c.revocation,
+			ReplayStore:    c.replayStore,
+			Algorithms:     []string{curveAlg},
+		}, nil
+	case ed25519.PublicKey:
+		if err := checkKeyAlgorithms(c.algorithms, eddsaAlgorithms); err != nil {
+			return nil, err
+		}
+
+		return &EdDSAVerifier{
+			PublicKey:      k,
+			Issuers:        c.issuers,
+			Audiences:      c.audiences,
+			Leeway:         c.leeway,
+			Clock:          c.clock,
+			RequiredClaims: c.requiredClaims,
+			MaxLifetime:    c.maxLifetime,
+			MaxAge:         c.maxAge,
+			Schema:         c.schema,
+			Revocation:     c.revocation,
+			ReplayStore:    c.replayStore,
+			Algorithms:     c.algorithms,
+		}, nil
+	case []byte:
+		if err := checkHMACSecret(k); err != nil {
+			return nil, err
+		}
+
+		if err := checkKeyAlgorithms(c.algorithms, hmacAlgorithms); err != nil {
+			return nil, err
+		}
+
+		return &HMACVerifier{
+			Secret:         k,
+			Issuers:        c.issuers,
+			Audiences:      c.audiences,
+			Leeway:         c.leeway,
+			Clock:          c.clock,
+			RequiredClaims: c.requiredClaims,
+			MaxLifetime:    c.maxLifetime,
+			MaxAge:         c.maxAge,
+			Schema:         c.schema,
+			Revocation:     c.revocation,
+			ReplayStore:    c.replayStore,
+			Algorithms:     c.algorithms,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T is not a public key", ErrUnsupportedKeyType, key)
+	}
+}
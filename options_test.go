@@ -0,0 +1,204 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestNewSigner_KeySources(t *testing.T) {
+	edKey, err := jwt.ParseEd25519PrivateKey([]byte(edPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name     string
+		signer   []jwt.SignerOption
+		verifier []jwt.VerifierOption
+	}{
+		{
+			"RSA file",
+			[]jwt.SignerOption{jwt.WithAFS(createAfs()), jwt.WithKeyFile("key.pem"), jwt.WithAlgorithm(jwt.PS384)},
+			[]jwt.VerifierOption{jwt.WithAFS(createAfs()), jwt.WithKeyFile("cert.pem")},
+		},
+		{
+			"ECDSA bytes",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(ecPrivateKey))},
+			[]jwt.VerifierOption{jwt.WithKeyBytes([]byte(ecPublicKey)), jwt.WithAlgorithm(jwt.ES256)},
+		},
+		{
+			"Ed25519 key",
+			[]jwt.SignerOption{jwt.WithKey(edKey)},
+			[]jwt.VerifierOption{jwt.WithKey(edKey.Public())},
+		},
+		{
+			"HMAC secret",
+			[]jwt.SignerOption{jwt.WithAFS(createAfs()), jwt.WithSecretFile("hmac.secret"), jwt.WithAlgorithm(jwt.HS512)},
+			[]jwt.VerifierOption{jwt.WithKey([]byte(hmacSecret)), jwt.WithAlgorithm(jwt.HS512)},
+		},
+		{
+			"encrypted RSA bytes",
+			[]jwt.SignerOption{
+				jwt.WithKeyBytes([]byte(rsaEncryptedPrivateKey)),
+				jwt.WithPassphrase(jwt.Passphrase([]byte(testPassphrase))),
+			},
+			[]jwt.VerifierOption{jwt.WithKeyBytes([]byte(rsaPublicKey))},
+		},
+		{
+			"verifier from private key",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(rsaPrivateKey))},
+			[]jwt.VerifierOption{jwt.WithKeyBytes([]byte(rsaPrivateKey))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := jwt.NewSigner(append(tt.signer,
+				jwt.WithIssuer("https://issuer.example.com"),
+				jwt.WithKeyID("test-key"),
+			)...)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			verifier, err := jwt.NewVerifier(append(tt.verifier,
+				jwt.WithIssuer("https://issuer.example.com"),
+				jwt.WithAudiences("test-audience"),
+				jwt.WithLeeway(time.Minute),
+			)...)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := verifier.Verify(token)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			expectString(t, "result.Issuer", result.Issuer, "https://issuer.example.com")
+			expectString(t, "result.KeyID", result.KeyID, "test-key")
+		})
+	}
+}
+
+func TestNewSigner_DefaultClaims(t *testing.T) {
+	signer, err := jwt.NewSigner(
+		jwt.WithKeyBytes([]byte(rsaPrivateKey)),
+		jwt.WithDefaultClaims(
+			jwt.String("tenant", "default-tenant"),
+			jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := signer.SignClaims(jwt.String("tenant", "override-tenant"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectStringElement(t, "result.Audience", result.Audience, "test-audience")
+	expectClaim(t, "result.Claims[tenant]", result.Claims, jwt.String("tenant", "override-tenant"))
+}
+
+func TestNewSigner_ShouldFail(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []jwt.SignerOption
+		expect error
+	}{
+		{"no key", nil, jwt.ErrInvalidOption},
+		{
+			"multiple keys",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(rsaPrivateKey)), jwt.WithKeyBytes([]byte(ecPrivateKey))},
+			jwt.ErrInvalidOption,
+		},
+		{
+			"algorithm for other key type",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(rsaPrivateKey)), jwt.WithAlgorithm(jwt.ES256)},
+			jwt.ErrInvalidOption,
+		},
+		{
+			"algorithm for other curve",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(ecPrivateKey)), jwt.WithAlgorithm(jwt.ES512)},
+			jwt.ErrInvalidOption,
+		},
+		{
+			"multiple issuers",
+			[]jwt.SignerOption{jwt.WithKeyBytes([]byte(rsaPrivateKey)), jwt.WithIssuer("a"), jwt.WithIssuer("b")},
+			jwt.ErrInvalidOption,
+		},
+		{"short secret", []jwt.SignerOption{jwt.WithKey([]byte("short"))}, jwt.ErrHMACSecretTooShort},
+		{"public key", []jwt.SignerOption{jwt.WithKeyBytes([]byte(rsaPKIXPublicKey))}, jwt.ErrUnsupportedPEMBlock},
+		{"missing file", []jwt.SignerOption{jwt.WithAFS(createAfs()), jwt.WithKeyFile("missing.pem")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.NewSigner(tt.opts...)
+			if err == nil {
+				t.Fatal("expected error to not be nil")
+			}
+
+			if tt.expect != nil {
+				expectErrMatch(t, "jwt.NewSigner()", err, tt.expect)
+			}
+		})
+	}
+}
+
+func TestNewVerifier_ShouldFail(t *testing.T) {
+	key := jwt.WithKeyBytes([]byte(rsaPublicKey))
+	audience := jwt.WithAudiences("test-audience")
+
+	tests := []struct {
+		name string
+		opts []jwt.VerifierOption
+	}{
+		{"no key", []jwt.VerifierOption{audience}},
+		{"no audience", []jwt.VerifierOption{key}},
+		{"empty audience list", []jwt.VerifierOption{key, jwt.WithAudiences()}},
+		{"algorithm for other key type", []jwt.VerifierOption{key, audience, jwt.WithAlgorithm(jwt.HS256)}},
+		{"negative leeway", []jwt.VerifierOption{key, audience, jwt.WithLeeway(-time.Second)}},
+		{"zero max age", []jwt.VerifierOption{key, audience, jwt.WithMaxAge(0)}},
+		{"key set and key", []jwt.VerifierOption{key, audience, jwt.WithKeySet(jwt.JWKSet{})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.NewVerifier(tt.opts...)
+			expectErrMatch(t, "jwt.NewVerifier()", err, jwt.ErrInvalidOption)
+		})
+	}
+}
+
+func TestNewVerifier_KeySet(t *testing.T) {
+	set, signers := createKeySet(t)
+
+	verifier, err := jwt.NewVerifier(
+		jwt.WithKeySet(set),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithRequiredClaims(jwt.Subject),
+		jwt.WithMaxLifetime(2*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if _, err := verifier.Verify(signKeySetToken(t, signers[2])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+}
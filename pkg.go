@@ -1,2 +1,2 @@
-// Package jwt provides a signing and verifier implementation using RSA keys.
+// Package jwt provides a signing and verifier implementation using RSA, ECDSA and Ed25519 keys or HMAC shared secrets.
 package jwt
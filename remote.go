@@ -0,0 +1,329 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRemoteCacheDuration is how long a remote key set is cached when the response
+	// has no usable Cache-Control max-age directive.
+	DefaultRemoteCacheDuration = time.Hour
+
+	// DefaultRemoteMinRefreshInterval is the minimum time between fetches of a remote key set.
+	DefaultRemoteMinRefreshInterval = time.Minute
+
+	// DefaultRemoteTimeout is how long a fetch of a remote key set can take before it is abandoned.
+	DefaultRemoteTimeout = 10 * time.Second
+
+	// maxRemoteKeySetSize is the maximum size of a remote key set document.
+	maxRemoteKeySetSize = 1 << 20
+)
+
+// ErrFetchKeySet is returned when the remote key set can not be fetched and there is no
+// previously fetched key set to fall back on.
+var ErrFetchKeySet = errors.New("unable to fetch key set")
+
+// RemoteKeySet is a `KeySet` that fetches a JWK Set from a URL, caching the keys for the
+// duration specified by the Cache-Control header of the response.
+//
+// Expired keys continue to be used while the key set is fetched again in the background, and
+// when a token has a key ID that is not in the cached set the key set is fetched before the
+// lookup is retried. Fetches are made no more often than MinRefreshInterval and are abandoned
+// after Timeout, the defaults are used for any of these that are not set. If a fetch fails the
+// last successfully fetched keys continue to be used.
+type RemoteKeySet struct {
+	URL                string
+	Client             *http.Client
+	CacheDuration      time.Duration
+	MinRefreshInterval time.Duration
+	Timeout            time.Duration
+
+	lock      sync.Mutex
+	keys      JWKSet
+	fetched   bool
+	expires   time.Time
+	lastFetch time.Time
+	lastErr   error
+	fetching  chan struct{}
+}
+
+// NewRemoteKeySet returns a `RemoteKeySet` for the JWK Set published at the URL supplied.
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	return &RemoteKeySet{
+		URL:                url,
+		Client:             http.DefaultClient,
+		CacheDuration:      DefaultRemoteCacheDuration,
+		MinRefreshInterval: DefaultRemoteMinRefreshInterval,
+		Timeout:            DefaultRemoteTimeout,
+	}
+}
+
+// NewKeySetVerifierFromURL returns a `KeySetVerifier` initialized with a `RemoteKeySet` for the
+// JWK Set published at the URL supplied and an audience for token verification.
+func NewKeySetVerifierFromURL(audiences []string, url string) (Verifier, error) {
+	if url == "" {
+		return nil, fmt.Errorf("%w: empty url", ErrFetchKeySet)
+	}
+
+	return &KeySetVerifier{
+		Audiences: audiences,
+		Keys:      NewRemoteKeySet(url),
+	}, nil
+}
+
+// LookupKeys returns the keys matching the key ID, or all keys when the key ID is empty,
+// fetching the key set if there are no cached keys or the key ID is unknown.
+func (s *RemoteKeySet) LookupKeys(kid string) ([]JWK, error) {
+	keys, fetched, expired := s.cached()
+
+	switch {
+	case !fetched:
+		// there are no keys to fall back on, so wait for the first fetch.
+		waitFetch(s.refreshAsync())
+
+		if keys, fetched, _ = s.cached(); !fetched {
+			return nil, s.fetchErr()
+		}
+	case expired:
+		s.refreshAsync()
+	}
+
+	found, err := keys.LookupKeys(kid)
+	if err == nil || kid == "" {
+		return found, err
+	}
+
+	done := s.refreshAsync()
+	if done == nil {
+		return found, err
+	}
+
+	waitFetch(done)
+
+	keys, _, _ = s.cached()
+
+	return keys.LookupKeys(kid)
+}
+
+// Refresh fetches the key set from the URL, replacing the cached keys.
+func (s *RemoteKeySet) Refresh(ctx context.Context) error {
+	now := time.Now()
+
+	s.lock.Lock()
+	s.lastFetch = now
+	s.lock.Unlock()
+
+	return s.refresh(ctx, now)
+}
+
+// Start refreshes the key set in the background each time the cached keys expire, until the
+// context is cancelled. Failed refreshes are retried after MinRefreshInterval.
+func (s *RemoteKeySet) Start(ctx context.Context) {
+	go func() {
+		for {
+			timer := time.NewTimer(s.nextRefresh())
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return
+			case <-timer.C:
+				_ = s.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// cached returns the cached keys, whether a key set has been fetched and whether it has expired.
+func (s *RemoteKeySet) cached() (JWKSet, bool, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.keys, s.fetched, !time.Now().Before(s.expires)
+}
+
+// fetchErr returns the error from the last failed fetch.
+func (s *RemoteKeySet) fetchErr() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.lastErr == nil {
+		return ErrFetchKeySet
+	}
+
+	return s.lastErr
+}
+
+// nextRefresh returns the delay before the next background refresh.
+func (s *RemoteKeySet) nextRefresh() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.lastFetch.IsZero() {
+		return 0
+	}
+
+	interval := s.minRefreshInterval()
+
+	delay := time.Until(s.expires)
+	if delay < interval {
+		delay = interval
+	}
+
+	return delay
+}
+
+// minRefreshInterval returns MinRefreshInterval, or DefaultRemoteMinRefreshInterval when it is
+// not set.
+func (s *RemoteKeySet) minRefreshInterval() time.Duration {
+	if s.MinRefreshInterval <= 0 {
+		return DefaultRemoteMinRefreshInterval
+	}
+
+	return s.MinRefreshInterval
+}
+
+// refreshAsync starts fetching the key set in the background, returning a channel that is
+// closed when the fetch completes. When a fetch is already in progress its channel is returned,
+// and nil is returned when the last fetch started less than MinRefreshInterval ago.
+func (s *RemoteKeySet) refreshAsync() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.fetching != nil {
+		return s.fetching
+	}
+
+	now := time.Now()
+	if !s.lastFetch.IsZero() && now.Sub(s.lastFetch) < s.minRefreshInterval() {
+		return nil
+	}
+
+	done := make(chan struct{})
+	s.fetching = done
+	s.lastFetch = now
+
+	go func() {
+		defer close(done)
+
+		_ = s.refresh(context.Background(), now)
+
+		s.lock.Lock()
+		s.fetching = nil
+		s.lock.Unlock()
+	}()
+
+	return done
+}
+
+// refresh fetches the key set without holding the lock and stores the result, a failed fetch
+// keeps the cached keys and is not retried for MinRefreshInterval.
+func (s *RemoteKeySet) refresh(ctx context.Context, now time.Time) error {
+	keys, maxAge, err := s.fetch(ctx)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err != nil {
+		s.lastErr = err
+		s.expires = now.Add(s.minRefreshInterval())
+
+		return err
+	}
+
+	s.keys = keys
+	s.fetched = true
+	s.lastErr = nil
+	s.expires = now.Add(maxAge)
+
+	return nil
+}
+
+// fetch retrieves and parses the key set and the cache duration from the response.
+func (s *RemoteKeySet) fetch(ctx context.Context) (JWKSet, time.Duration, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return JWKSet{}, 0, fmt.Errorf("%w: %w", ErrFetchKeySet, err)
+	}
+
+	req.Header.Set("Accept", "application/jwk-set+json, application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return JWKSet{}, 0, fmt.Errorf("%w: %w", ErrFetchKeySet, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return JWKSet{}, 0, fmt.Errorf("%w: unexpected status %q", ErrFetchKeySet, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteKeySetSize))
+	if err != nil {
+		return JWKSet{}, 0, fmt.Errorf("%w: %w", ErrFetchKeySet, err)
+	}
+
+	var keys JWKSet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return JWKSet{}, 0, fmt.Errorf("%w: %w", ErrFetchKeySet, err)
+	}
+
+	return keys, s.cacheDuration(resp.Header.Get("Cache-Control")), nil
+}
+
+// cacheDuration returns the duration to cache the key set from the Cache-Control header value,
+// falling back to CacheDuration when there is no max-age directive.
+func (s *RemoteKeySet) cacheDuration(cacheControl string) time.Duration {
+	fallback := s.CacheDuration
+	if fallback <= 0 {
+		fallback = DefaultRemoteCacheDuration
+	}
+
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age":
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err != nil || seconds < 0 {
+				return fallback
+			}
+
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	return fallback
+}
+
+// waitFetch blocks until the channel is closed, a nil channel does not block.
+func waitFetch(done <-chan struct{}) {
+	if done != nil {
+		<-done
+	}
+}
@@ -0,0 +1,388 @@
+package jwt_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+type keySetServer struct {
+	*httptest.Server
+
+	lock         sync.Mutex
+	set          jwt.JWKSet
+	cacheControl string
+	fail         bool
+	hang         chan struct{}
+	requests     atomic.Int32
+}
+
+func newKeySetServer(t *testing.T, set jwt.JWKSet, cacheControl string) *keySetServer {
+	t.Helper()
+
+	s := &keySetServer{set: set, cacheControl: cacheControl}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.requests.Add(1)
+
+		s.lock.Lock()
+		hang := s.hang
+		s.lock.Unlock()
+
+		if hang != nil {
+			<-hang
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		if s.fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/jwk-set+json")
+		w.Header().Set("Cache-Control", s.cacheControl)
+		_ = json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *keySetServer) update(set jwt.JWKSet, fail bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.set = set
+	s.fail = fail
+}
+
+// block makes requests hang until the returned function is called.
+func (s *keySetServer) block() func() {
+	hang := make(chan struct{})
+
+	s.lock.Lock()
+	s.hang = hang
+	s.lock.Unlock()
+
+	return func() {
+		s.lock.Lock()
+		s.hang = nil
+		s.lock.Unlock()
+
+		close(hang)
+	}
+}
+
+// waitForRequests waits for the server to receive at least the number of requests supplied.
+func (s *keySetServer) waitForRequests(requests int32) {
+	deadline := time.Now().Add(5 * time.Second)
+	for s.requests.Load() < requests && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func signKeySetToken(t *testing.T, signer jwt.Signer) []byte {
+	t.Helper()
+
+	token, err := signer.SignClaims(
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Issued, time.Now()),
+		jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return token
+}
+
+func TestRemoteKeySet_ShouldSucceed_CachesKeys(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, set, "public, max-age=3600")
+
+	verifier, err := jwt.NewKeySetVerifierFromURL([]string{"test-audience"}, server.URL)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	for _, signer := range signers {
+		result, err := verifier.Verify(signKeySetToken(t, signer))
+		if err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+
+		expectString(t, "result.Subject", result.Subject, "subject")
+	}
+
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("server.requests: expected '1', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldSucceed_NoCacheRefetches(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, set, "no-cache")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Nanosecond
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	for range 2 {
+		if _, err := verifier.Verify(signKeySetToken(t, signers[0])); err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+	}
+
+	server.waitForRequests(2)
+
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("server.requests: expected '2', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldSucceed_NoCacheRateLimited(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, set, "no-cache")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Hour
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	for range 3 {
+		if _, err := verifier.Verify(signKeySetToken(t, signers[0])); err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+	}
+
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("server.requests: expected '1', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldSucceed_ServesCachedKeysWhileRefreshing(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, set, "max-age=0")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Nanosecond
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	if _, err := verifier.Verify(signKeySetToken(t, signers[0])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	token := signKeySetToken(t, signers[1])
+	release := server.block()
+	defer release()
+
+	done := make(chan error)
+
+	go func() {
+		_, err := verifier.Verify(token)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected verify to not wait for the key set refresh")
+	}
+}
+
+func TestRemoteKeySet_ShouldFail_Timeout(t *testing.T) {
+	server := newKeySetServer(t, jwt.JWKSet{}, "")
+
+	release := server.block()
+	defer release()
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.Timeout = 10 * time.Millisecond
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	_, err := verifier.Verify(signKeySetToken(t, createSigner(t)))
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrFetchKeySet)
+	expectErrMatch(t, "verifier.Verify()", err, context.DeadlineExceeded)
+}
+
+func TestRemoteKeySet_ShouldSucceed_UnknownKeyIDRefetches(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, jwt.JWKSet{Keys: set.Keys[:1]}, "max-age=3600")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Nanosecond
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	if _, err := verifier.Verify(signKeySetToken(t, signers[0])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	server.update(set, false)
+
+	result, err := verifier.Verify(signKeySetToken(t, signers[1]))
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "result.KeyID", result.KeyID, "ec-key")
+
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("server.requests: expected '2', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldFail_UnknownKeyIDRateLimited(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, jwt.JWKSet{Keys: set.Keys[:1]}, "max-age=3600")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Hour
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	for range 3 {
+		_, err := verifier.Verify(signKeySetToken(t, signers[1]))
+		expectErrMatch(t, "verifier.Verify()", err, jwt.ErrKeyNotFound)
+	}
+
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("server.requests: expected '1', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldSucceed_ServesLastGoodSet(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, set, "no-store")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Nanosecond
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	if _, err := verifier.Verify(signKeySetToken(t, signers[0])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	server.update(jwt.JWKSet{}, true)
+
+	if _, err := verifier.Verify(signKeySetToken(t, signers[2])); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	if err := keySet.Refresh(context.Background()); err == nil {
+		t.Error("expected error to not be nil")
+	}
+}
+
+func TestRemoteKeySet_ShouldFail_Unavailable(t *testing.T) {
+	server := newKeySetServer(t, jwt.JWKSet{}, "")
+	server.update(jwt.JWKSet{}, true)
+
+	verifier, err := jwt.NewKeySetVerifierFromURL([]string{"test-audience"}, server.URL)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(signKeySetToken(t, createSigner(t)))
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrFetchKeySet)
+}
+
+func TestRemoteKeySet_ShouldFail_UnavailableRateLimited(t *testing.T) {
+	server := newKeySetServer(t, jwt.JWKSet{}, "")
+	server.update(jwt.JWKSet{}, true)
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Hour
+	verifier := &jwt.KeySetVerifier{Keys: keySet, Audiences: []string{"test-audience"}}
+
+	for range 3 {
+		_, err := verifier.Verify(signKeySetToken(t, createSigner(t)))
+		expectErrMatch(t, "verifier.Verify()", err, jwt.ErrFetchKeySet)
+	}
+
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("server.requests: expected '1', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldFail_BackgroundRefreshBacksOff(t *testing.T) {
+	server := newKeySetServer(t, jwt.JWKSet{}, "")
+	server.update(jwt.JWKSet{}, true)
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keySet.Start(ctx)
+	server.waitForRequests(1)
+	time.Sleep(100 * time.Millisecond)
+
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("server.requests: expected '1', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldSucceed_BackgroundRefresh(t *testing.T) {
+	set, _ := createKeySet(t)
+	server := newKeySetServer(t, set, "max-age=0")
+
+	keySet := jwt.NewRemoteKeySet(server.URL)
+	keySet.MinRefreshInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keySet.Start(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for server.requests.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if requests := server.requests.Load(); requests < 3 {
+		t.Errorf("server.requests: expected at least '3', returned '%d'", requests)
+	}
+}
+
+func TestRemoteKeySet_ShouldFail_ZeroValueRateLimited(t *testing.T) {
+	set, signers := createKeySet(t)
+	server := newKeySetServer(t, jwt.JWKSet{Keys: set.Keys[:1]}, "no-store")
+
+	verifier := &jwt.KeySetVerifier{
+		Keys:      &jwt.RemoteKeySet{URL: server.URL},
+		Audiences: []string{"test-audience"},
+	}
+
+	for range 3 {
+		_, err := verifier.Verify(signKeySetToken(t, signers[1]))
+		expectErrMatch(t, "verifier.Verify()", err, jwt.ErrKeyNotFound)
+	}
+
+	server.update(jwt.JWKSet{}, true)
+
+	unavailable := &jwt.KeySetVerifier{
+		Keys:      &jwt.RemoteKeySet{URL: server.URL},
+		Audiences: []string{"test-audience"},
+	}
+
+	for range 3 {
+		_, err := unavailable.Verify(signKeySetToken(t, signers[0]))
+		expectErrMatch(t, "verifier.Verify()", err, jwt.ErrFetchKeySet)
+	}
+
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("server.requests: expected '2', returned '%d'", requests)
+	}
+}
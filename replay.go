@@ -0,0 +1,201 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
+)
+
+// ErrTokenReplayed is the error returned when a token ID has already been recorded by a `ReplayStore`.
+var ErrTokenReplayed = errors.New("token has already been used")
+
+// DefaultReplaySweepInterval is the interval between removing expired token IDs from a
+// `MemoryReplayStore` when SweepInterval is not set.
+const DefaultReplaySweepInterval = time.Minute
+
+// ReplayStore records the IDs of verified tokens so that one-time tokens can not be reused.
+type ReplayStore interface {
+	// Record stores the token ID until the expiry time, returning `ErrTokenReplayed` if the ID
+	// is already stored and has not expired.
+	Record(id string, expires time.Time) error
+}
+
+// checkReplay records the token ID in the replay store, the token is rejected if it has been
+// seen before. The token must have "jti" and "exp" claims so the ID can be forgotten once the
+// token is no longer valid.
+func checkReplay(claims *pascaljwt.Claims, policy claimsPolicy) error {
+	if policy.replay == nil {
+		return nil
+	}
+
+	if claims.ID == "" {
+		return &VerificationError{Reason: ErrTokenMissingClaim, Claim: ID}
+	}
+
+	if claims.Expires == nil {
+		return &VerificationError{Reason: ErrTokenMissingClaim, Claim: Expires}
+	}
+
+	err := policy.replay.Record(claims.ID, claims.Expires.Time().Add(policy.leeway))
+	if errors.Is(err, ErrTokenReplayed) {
+		return &VerificationError{Reason: ErrTokenReplayed, Claim: ID}
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to record token ID: %w", err)
+	}
+
+	return nil
+}
+
+// MemoryReplayStore is a `ReplayStore` that keeps token IDs in memory, expired IDs are removed
+// every SweepInterval (or `DefaultReplaySweepInterval` when not set) as tokens are recorded, or
+// in the background after calling Start.
+//
+// Expiry is checked against the time from Clock, or the system time when Clock is nil.
+type MemoryReplayStore struct {
+	Clock         Clock
+	SweepInterval time.Duration
+
+	lock  sync.Mutex
+	ids   map[string]time.Time
+	swept time.Time
+}
+
+// NewMemoryReplayStore returns an empty `MemoryReplayStore`.
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{}
+}
+
+// Record stores the token ID until the expiry time, returning `ErrTokenReplayed` if the ID
+// is already stored and has not expired.
+func (s *MemoryReplayStore) Record(id string, expires time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := clockNow(s.Clock)
+
+	if now.Sub(s.swept) >= s.sweepInterval() {
+		s.sweep(now)
+	}
+
+	if until, ok := s.ids[id]; ok && now.Before(until) {
+		return ErrTokenReplayed
+	}
+
+	if s.ids == nil {
+		s.ids = map[string]time.Time{}
+	}
+
+	s.ids[id] = expires
+
+	return nil
+}
+
+// Len returns the number of token IDs stored, including any that have expired since the last sweep.
+func (s *MemoryReplayStore) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.ids)
+}
+
+// Sweep removes the expired token IDs.
+func (s *MemoryReplayStore) Sweep() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sweep(clockNow(s.Clock))
+}
+
+// Start sweeps expired token IDs in the background every SweepInterval, until the context is cancelled.
+func (s *MemoryReplayStore) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.sweepInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Sweep()
+			}
+		}
+	}()
+}
+
+func (s *MemoryReplayStore) sweep(now time.Time) {
+	for id, until := range s.ids {
+		if !now.Before(until) {
+			delete(s.ids, id)
+		}
+	}
+
+	s.swept = now
+}
+
+func (s *MemoryReplayStore) sweepInterval() time.Duration {
+	if s.SweepInterval > 0 {
+		return s.SweepInterval
+	}
+
+	return DefaultReplaySweepInterval
+}
+
+// FileReplayStore is a `ReplayStore` that keeps token IDs in a JSON file so they survive a
+// restart, expired IDs are removed each time a token is recorded.
+//
+// The file is only locked within the process, it should not be shared between processes.
+type FileReplayStore struct {
+	Fs       afero.Fs
+	Filename string
+	Clock    Clock
+
+	lock sync.Mutex
+}
+
+// NewFileReplayStore returns a `FileReplayStore` that stores token IDs in the named file.
+func NewFileReplayStore(filename string) *FileReplayStore {
+	return NewFileReplayStoreAFS(afero.NewOsFs(), filename)
+}
+
+// NewFileReplayStoreAFS returns a `FileReplayStore` that stores token IDs in the named file
+// with a supplied `afero.Fs`.
+func NewFileReplayStoreAFS(afs afero.Fs, filename string) *FileReplayStore {
+	return &FileReplayStore{Fs: afs, Filename: filename}
+}
+
+// Record stores the token ID until the expiry time, returning `ErrTokenReplayed` if the ID
+// is already stored and has not expired.
+func (s *FileReplayStore) Record(id string, expires time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := map[string]time.Time{}
+	if err := readJSONFile(s.Fs, s.Filename, &ids); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	now := clockNow(s.Clock)
+
+	if until, ok := ids[id]; ok && now.Before(until) {
+		return ErrTokenReplayed
+	}
+
+	for k, until := range ids {
+		if !now.Before(until) {
+			delete(ids, k)
+		}
+	}
+
+	ids[id] = expires
+
+	return writeJSONFile(s.Fs, s.Filename, ids)
+}
@@ -0,0 +1,201 @@
+package jwt_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+	"github.com/spf13/afero"
+)
+
+func TestReplayStore_Verifier(t *testing.T) {
+	stores := map[string]jwt.ReplayStore{
+		"memory": jwt.NewMemoryReplayStore(),
+		"file":   jwt.NewFileReplayStoreAFS(afero.NewMemMapFs(), "replay.json"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			token, err := createSigner(t).SignClaims(
+				jwt.Strings(jwt.Audience, []string{"test-audience"}),
+				jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+			)
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.ReplayStore = store
+
+			if _, err := verifier.Verify(token); err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			_, err = verifier.Verify(token)
+			expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenReplayed)
+
+			var verr *jwt.VerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected err '%v' to be a *jwt.VerificationError", err)
+			}
+
+			expectString(t, "verr.Claim", verr.Claim, jwt.ID)
+		})
+	}
+}
+
+func TestReplayStore_Verifier_ShouldFail_MissingExpires(t *testing.T) {
+	token, err := createSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.ReplayStore = jwt.NewMemoryReplayStore()
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenMissingClaim)
+}
+
+func TestReplayStore_Verifier_RejectedTokenNotRecorded(t *testing.T) {
+	store := jwt.NewMemoryReplayStore()
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"other-audience"}),
+		jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.ReplayStore = store
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenInvalidAudience)
+
+	if store.Len() != 0 {
+		t.Errorf("store.Len(): expected '0', returned '%d'", store.Len())
+	}
+}
+
+func TestReplayStore_Verifier_FailedCheckNotRecorded(t *testing.T) {
+	store := jwt.NewMemoryReplayStore()
+	errCheck := errors.New("check failed")
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.ReplayStore = store
+
+	_, err = verifier.VerifyWithChecks(token, func(jwt.VerifyResult) error { return errCheck })
+	expectErrMatch(t, "verifier.VerifyWithChecks()", err, errCheck)
+
+	if store.Len() != 0 {
+		t.Errorf("store.Len(): expected '0', returned '%d'", store.Len())
+	}
+
+	if _, err := verifier.VerifyWithChecks(token, func(jwt.VerifyResult) error { return nil }); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenReplayed)
+}
+
+func TestMemoryReplayStore_Expiry(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	store := jwt.NewMemoryReplayStore()
+	store.Clock = jwt.ClockFunc(func() time.Time { return now })
+
+	if err := store.Record("a", now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if err := store.Record("b", now.Add(time.Hour)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectErrMatch(t, "store.Record()", store.Record("a", now.Add(time.Minute)), jwt.ErrTokenReplayed)
+
+	now = now.Add(2 * time.Minute)
+
+	store.Sweep()
+
+	if store.Len() != 1 {
+		t.Errorf("store.Len(): expected '1', returned '%d'", store.Len())
+	}
+
+	if err := store.Record("a", now.Add(time.Minute)); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectErrMatch(t, "store.Record()", store.Record("b", now.Add(time.Hour)), jwt.ErrTokenReplayed)
+}
+
+func TestFileReplayStore_Persistence(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clock := jwt.ClockFunc(func() time.Time { return now })
+	afs := afero.NewMemMapFs()
+
+	store := jwt.NewFileReplayStoreAFS(afs, "replay.json")
+	store.Clock = clock
+
+	if err := store.Record("a", now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	restarted := jwt.NewFileReplayStoreAFS(afs, "replay.json")
+	restarted.Clock = clock
+
+	expectErrMatch(t, "restarted.Record()", restarted.Record("a", now.Add(time.Minute)), jwt.ErrTokenReplayed)
+
+	now = now.Add(2 * time.Minute)
+
+	if err := restarted.Record("a", now.Add(time.Minute)); err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+}
+
+func TestWithReplayStore(t *testing.T) {
+	_, err := jwt.NewVerifier(
+		jwt.WithAFS(createAfs()),
+		jwt.WithKeyFile("cert.pem"),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithReplayStore(nil),
+	)
+	expectErrMatch(t, "jwt.NewVerifier()", err, jwt.ErrInvalidOption)
+
+	verifier, err := jwt.NewVerifier(
+		jwt.WithAFS(createAfs()),
+		jwt.WithKeyFile("cert.pem"),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithReplayStore(jwt.NewMemoryReplayStore()),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Expires, time.Now().Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if _, err := verifier.Verify(token); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenReplayed)
+}
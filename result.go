@@ -0,0 +1,228 @@
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrClaimNotFound is returned when a claim is not present in the `VerifyResult`.
+var ErrClaimNotFound = errors.New("claim not found")
+
+// Claim returns the named claim, or `ErrClaimNotFound` if it is not present.
+func (r VerifyResult) Claim(key string) (Claim, error) {
+	claim, ok := r.Claims[key]
+	if !ok {
+		return Claim{}, fmt.Errorf("%w: %s", ErrClaimNotFound, key)
+	}
+
+	return claim, nil
+}
+
+// GetString returns the value of a string claim.
+func (r VerifyResult) GetString(key string) (string, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch claim.Type { //nolint:exhaustive // other types are not strings.
+	case StringType:
+		return claim.String, nil
+	default:
+		if v, ok := claim.Interface.(string); ok {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetInt64 returns the value of an integer claim, JSON numbers are accepted if they have
+// no fractional part and are in range.
+func (r VerifyResult) GetInt64(key string) (int64, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return 0, err
+	}
+
+	switch claim.Type { //nolint:exhaustive // other types are handled as numbers.
+	case Int8Type, Int16Type, Int32Type, Int64Type:
+		return claim.Integer, nil
+	default:
+		f, ok := claimNumber(claim)
+		if ok && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetFloat64 returns the value of a numeric claim.
+func (r VerifyResult) GetFloat64(key string) (float64, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if f, ok := claimNumber(claim); ok {
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetBool returns the value of a boolean claim.
+func (r VerifyResult) GetBool(key string) (bool, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return false, err
+	}
+
+	if v, ok := claim.Interface.(bool); ok {
+		return v, nil
+	}
+
+	return false, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetStrings returns the value of a string array claim, a single string is returned as a
+// one element slice.
+func (r VerifyResult) GetStrings(key string) ([]string, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.Type == StringType {
+		return []string{claim.String}, nil
+	}
+
+	switch v := claim.Interface.(type) {
+	case []string:
+		return v, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		o := make([]string, 0, len(v))
+
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+			}
+
+			o = append(o, s)
+		}
+
+		return o, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetTime returns the value of a time claim, JSON numbers are decoded as a NumericDate
+// (seconds since the epoch) and strings as RFC 3339.
+func (r VerifyResult) GetTime(key string) (time.Time, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if claim.Type == TimeType {
+		return claim.Time()
+	}
+
+	if f, ok := claimNumber(claim); ok {
+		return numericDate(f), nil
+	}
+
+	if s, ok := claim.Interface.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetDuration returns the value of a duration claim, JSON numbers are decoded as seconds
+// and strings in the `time.ParseDuration` format.
+func (r VerifyResult) GetDuration(key string) (time.Duration, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if claim.Type == DurationType {
+		return time.Duration(claim.Integer), nil
+	}
+
+	if f, ok := claimNumber(claim); ok {
+		return time.Duration(math.Round(f * float64(time.Second))), nil
+	}
+
+	s := claim.String
+	if v, ok := claim.Interface.(string); ok {
+		s = v
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	return 0, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// GetObject returns the value of a JSON object claim.
+func (r VerifyResult) GetObject(key string) (map[string]interface{}, error) {
+	claim, err := r.Claim(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := claim.Interface.(map[string]interface{}); ok {
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+}
+
+// claimNumber returns the numeric value of a numeric claim or a decoded JSON number.
+func claimNumber(claim Claim) (float64, bool) {
+	switch claim.Type { //nolint:exhaustive // other types are checked by value.
+	case Int8Type, Int16Type, Int32Type, Int64Type:
+		return float64(claim.Integer), true
+	case Uint8Type, Uint16Type, Uint32Type, Uint64Type, UintptrType:
+		return float64(uint64(claim.Integer)), true //nolint:gosec // stored as bits.
+	case Float64Type:
+		return math.Float64frombits(uint64(claim.Integer)), true //nolint:gosec // stored as bits.
+	case Float32Type:
+		return float64(math.Float32frombits(uint32(claim.Integer))), true //nolint:gosec // stored as bits.
+	case StringType, TimeType, DurationType:
+		return 0, false
+	}
+
+	switch v := claim.Interface.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// numericDate returns the time for a NumericDate in seconds since the epoch.
+func numericDate(seconds float64) time.Time {
+	whole, frac := math.Modf(seconds)
+
+	return time.Unix(int64(whole), int64(math.Round(frac*float64(time.Second))))
+}
@@ -0,0 +1,139 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func createResult(t *testing.T, claims ...jwt.Claim) jwt.VerifyResult {
+	t.Helper()
+
+	token, err := createSigner(t).SignClaims(append(claims, jwt.Strings(jwt.Audience, []string{"test-audience"}))...)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return result
+}
+
+func TestVerifyResult_Getters(t *testing.T) {
+	custom := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+	expTime := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	result := createResult(t,
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Time(jwt.Expires, expTime),
+		jwt.String("name", "test user"),
+		jwt.Int64("count", 42),
+		jwt.Bool("admin", true),
+		jwt.Strings("groups", []string{"a", "b"}),
+		jwt.Time("custom_time", custom),
+		jwt.String("ttl", "1h30m"),
+	)
+
+	if v, err := result.GetString("name"); err != nil || v != "test user" {
+		t.Errorf("GetString(name): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetString(jwt.Subject); err != nil || v != "subject" {
+		t.Errorf("GetString(sub): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetInt64("count"); err != nil || v != 42 {
+		t.Errorf("GetInt64(count): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetFloat64("count"); err != nil || v != 42 {
+		t.Errorf("GetFloat64(count): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetBool("admin"); err != nil || !v {
+		t.Errorf("GetBool(admin): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetStrings("groups"); err != nil || len(v) != 2 || v[0] != "a" || v[1] != "b" {
+		t.Errorf("GetStrings(groups): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetStrings(jwt.Audience); err != nil || len(v) != 1 || v[0] != "test-audience" {
+		t.Errorf("GetStrings(aud): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetTime("custom_time"); err != nil || !v.Equal(custom) {
+		t.Errorf("GetTime(custom_time): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetTime(jwt.Expires); err != nil || !v.Equal(expTime) {
+		t.Errorf("GetTime(exp): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetDuration("ttl"); err != nil || v != 90*time.Minute {
+		t.Errorf("GetDuration(ttl): returned '%v', '%v'", v, err)
+	}
+
+	if v, err := result.GetString(jwt.ID); err != nil || v != result.ID {
+		t.Errorf("GetString(jti): returned '%v', '%v'", v, err)
+	}
+}
+
+func TestVerifyResult_GetObject(t *testing.T) {
+	result := jwt.VerifyResult{
+		Claims: map[string]jwt.Claim{
+			"address": jwt.Any("address", map[string]interface{}{"city": "Brisbane"}),
+			"ratio":   jwt.Any("ratio", 1.5),
+			"seconds": jwt.Any("seconds", float64(30)),
+		},
+	}
+
+	object, err := result.GetObject("address")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if city, _ := object["city"].(string); city != "Brisbane" {
+		t.Errorf("object[city]: expected 'Brisbane', returned '%v'", object["city"])
+	}
+
+	if v, err := result.GetFloat64("ratio"); err != nil || v != 1.5 {
+		t.Errorf("GetFloat64(ratio): returned '%v', '%v'", v, err)
+	}
+
+	_, err = result.GetInt64("ratio")
+	expectErrMatch(t, "GetInt64(ratio)", err, jwt.ErrInvalidClaimType)
+
+	if v, err := result.GetDuration("seconds"); err != nil || v != 30*time.Second {
+		t.Errorf("GetDuration(seconds): returned '%v', '%v'", v, err)
+	}
+}
+
+func TestVerifyResult_GettersShouldFail(t *testing.T) {
+	result := createResult(t, jwt.String("name", "test user"), jwt.Int64("count", 42))
+
+	_, err := result.GetString("missing")
+	expectErrMatch(t, "GetString(missing)", err, jwt.ErrClaimNotFound)
+
+	_, err = result.GetInt64("name")
+	expectErrMatch(t, "GetInt64(name)", err, jwt.ErrInvalidClaimType)
+
+	_, err = result.GetBool("count")
+	expectErrMatch(t, "GetBool(count)", err, jwt.ErrInvalidClaimType)
+
+	_, err = result.GetString("count")
+	expectErrMatch(t, "GetString(count)", err, jwt.ErrInvalidClaimType)
+
+	_, err = result.GetTime("name")
+	expectErrMatch(t, "GetTime(name)", err, jwt.ErrInvalidClaimType)
+
+	_, err = result.GetObject("name")
+	expectErrMatch(t, "GetObject(name)", err, jwt.ErrInvalidClaimType)
+
+	_, err = result.GetDuration("name")
+	expectErrMatch(t, "GetDuration(name)", err, jwt.ErrInvalidClaimType)
+}
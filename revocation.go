@@ -0,0 +1,202 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// ErrTokenRevoked is the error returned when a `RevocationChecker` reports a token has been revoked.
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// RevocationChecker reports whether a token has been revoked, it is consulted by verifiers after
+// the signature and claims have been checked.
+type RevocationChecker interface {
+	// IsRevoked returns true if the token ID has been revoked, or all tokens for the subject
+	// issued before a time after the issued time have been revoked.
+	IsRevoked(id, subject string, issued time.Time) (bool, error)
+}
+
+// checkRevocation returns `ErrTokenRevoked` if the revocation checker reports the token as revoked.
+func checkRevocation(result VerifyResult, policy claimsPolicy) error {
+	if policy.revocation == nil {
+		return nil
+	}
+
+	revoked, err := policy.revocation.IsRevoked(result.ID, result.Subject, result.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("unable to check token revocation: %w", err)
+	}
+
+	if revoked {
+		return &VerificationError{Reason: ErrTokenRevoked}
+	}
+
+	return nil
+}
+
+// revocations is the list of revoked token IDs, with the time they can be forgotten, and the
+// times before which all tokens for a subject are revoked.
+type revocations struct {
+	Tokens   map[string]time.Time `json:"tokens,omitempty"`
+	Subjects map[string]time.Time `json:"subjects,omitempty"`
+}
+
+func (r *revocations) revokeToken(id string, expires time.Time) {
+	if r.Tokens == nil {
+		r.Tokens = map[string]time.Time{}
+	}
+
+	r.Tokens[id] = expires
+}
+
+func (r *revocations) revokeSubject(subject string, before time.Time) {
+	if r.Subjects == nil {
+		r.Subjects = map[string]time.Time{}
+	}
+
+	if current, ok := r.Subjects[subject]; !ok || before.After(current) {
+		r.Subjects[subject] = before
+	}
+}
+
+func (r *revocations) isRevoked(id, subject string, issued time.Time) bool {
+	if _, ok := r.Tokens[id]; ok && id != "" {
+		return true
+	}
+
+	// a token without an issued time can not be shown to be issued after the revocation.
+	before, ok := r.Subjects[subject]
+
+	return ok && subject != "" && (issued.IsZero() || issued.Before(before))
+}
+
+// sweep removes the revoked token IDs that have expired, a zero expiry is kept forever.
+func (r *revocations) sweep(now time.Time) {
+	for id, expires := range r.Tokens {
+		if !expires.IsZero() && !now.Before(expires) {
+			delete(r.Tokens, id)
+		}
+	}
+}
+
+// MemoryRevocationList is a `RevocationChecker` that keeps revoked token IDs and subjects in memory.
+//
+// Revoked token IDs are forgotten once their expiry time (from Clock, or the system time when
+// Clock is nil) has passed, as the token would be rejected anyway.
+type MemoryRevocationList struct {
+	Clock Clock
+
+	lock sync.RWMutex
+	list revocations
+}
+
+// NewMemoryRevocationList returns an empty `MemoryRevocationList`.
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return &MemoryRevocationList{}
+}
+
+// RevokeToken revokes the token ID until the token expires, a zero expiry revokes it forever.
+func (l *MemoryRevocationList) RevokeToken(id string, expires time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.list.sweep(clockNow(l.Clock))
+	l.list.revokeToken(id, expires)
+}
+
+// RevokeSubject revokes all tokens for the subject issued before the time.
+func (l *MemoryRevocationList) RevokeSubject(subject string, before time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.list.revokeSubject(subject, before)
+}
+
+// IsRevoked returns true if the token ID or the subject has been revoked.
+func (l *MemoryRevocationList) IsRevoked(id, subject string, issued time.Time) (bool, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.list.isRevoked(id, subject, issued), nil
+}
+
+// FileRevocationList is a `RevocationChecker` that keeps revoked token IDs and subjects in a JSON
+// file, so revocations survive a restart and can be shared by processes reading the same file.
+//
+// The file is read each time a token is checked, and only locked within the process.
+type FileRevocationList struct {
+	Fs       afero.Fs
+	Filename string
+	Clock    Clock
+
+	lock sync.Mutex
+}
+
+// NewFileRevocationList returns a `FileRevocationList` that stores revocations in the named file.
+func NewFileRevocationList(filename string) *FileRevocationList {
+	return NewFileRevocationListAFS(afero.NewOsFs(), filename)
+}
+
+// NewFileRevocationListAFS returns a `FileRevocationList` that stores revocations in the named
+// file with a supplied `afero.Fs`.
+func NewFileRevocationListAFS(afs afero.Fs, filename string) *FileRevocationList {
+	return &FileRevocationList{Fs: afs, Filename: filename}
+}
+
+// RevokeToken revokes the token ID until the token expires, a zero expiry revokes it forever.
+func (l *FileRevocationList) RevokeToken(id string, expires time.Time) error {
+	return l.update(func(list *revocations) {
+		list.sweep(clockNow(l.Clock))
+		list.revokeToken(id, expires)
+	})
+}
+
+// RevokeSubject revokes all tokens for the subject issued before the time.
+func (l *FileRevocationList) RevokeSubject(subject string, before time.Time) error {
+	return l.update(func(list *revocations) {
+		list.revokeSubject(subject, before)
+	})
+}
+
+// IsRevoked returns true if the token ID or the subject has been revoked.
+func (l *FileRevocationList) IsRevoked(id, subject string, issued time.Time) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	list, err := l.read()
+	if err != nil {
+		return false, err
+	}
+
+	return list.isRevoked(id, subject, issued), nil
+}
+
+func (l *FileRevocationList) update(fn func(list *revocations)) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	list, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	fn(&list)
+
+	return writeJSONFile(l.Fs, l.Filename, list)
+}
+
+// read returns the revocations from the file, a missing file has no revocations.
+func (l *FileRevocationList) read() (revocations, error) {
+	list := revocations{}
+
+	if err := readJSONFile(l.Fs, l.Filename, &list); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return list, err
+	}
+
+	return list, nil
+}
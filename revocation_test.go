@@ -0,0 +1,171 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+	"github.com/spf13/afero"
+)
+
+type revocationList interface {
+	jwt.RevocationChecker
+	RevokeToken(id string, expires time.Time) error
+	RevokeSubject(subject string, before time.Time) error
+}
+
+// memoryRevocationList adapts the `MemoryRevocationList` to the error returning methods of the
+// `FileRevocationList` so both can be tested together.
+type memoryRevocationList struct {
+	*jwt.MemoryRevocationList
+}
+
+func (l memoryRevocationList) RevokeToken(id string, expires time.Time) error {
+	l.MemoryRevocationList.RevokeToken(id, expires)
+
+	return nil
+}
+
+func (l memoryRevocationList) RevokeSubject(subject string, before time.Time) error {
+	l.MemoryRevocationList.RevokeSubject(subject, before)
+
+	return nil
+}
+
+func TestRevocationChecker_Verifier(t *testing.T) {
+	issued := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	lists := map[string]func() revocationList{
+		"memory": func() revocationList { return memoryRevocationList{jwt.NewMemoryRevocationList()} },
+		"file":   func() revocationList { return jwt.NewFileRevocationListAFS(afero.NewMemMapFs(), "revoked.json") },
+	}
+
+	tests := []struct {
+		name    string
+		revoke  func(list revocationList) error
+		revoked bool
+	}{
+		{"not revoked", func(revocationList) error { return nil }, false},
+		{"token id", func(list revocationList) error {
+			return list.RevokeToken("token-id", issued.Add(time.Hour))
+		}, true},
+		{"other token id", func(list revocationList) error {
+			return list.RevokeToken("other-id", issued.Add(time.Hour))
+		}, false},
+		{"subject issued before", func(list revocationList) error {
+			return list.RevokeSubject("subject", issued.Add(time.Second))
+		}, true},
+		{"subject issued after", func(list revocationList) error {
+			return list.RevokeSubject("subject", issued.Add(-time.Second))
+		}, false},
+		{"subject latest revocation kept", func(list revocationList) error {
+			if err := list.RevokeSubject("subject", issued.Add(time.Second)); err != nil {
+				return err
+			}
+
+			return list.RevokeSubject("subject", issued.Add(-time.Second))
+		}, true},
+		{"other subject", func(list revocationList) error {
+			return list.RevokeSubject("other-subject", issued.Add(time.Second))
+		}, false},
+	}
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.String(jwt.ID, "token-id"),
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Time(jwt.Issued, issued),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	for name, newList := range lists {
+		for _, tt := range tests {
+			t.Run(name+" "+tt.name, func(t *testing.T) {
+				list := newList()
+				if err := tt.revoke(list); err != nil {
+					t.Fatalf("expected error to be nil, returned '%v'", err)
+				}
+
+				verifier := createVerifier(t).(*jwt.RSAVerifier)
+				verifier.Revocation = list
+
+				_, err := verifier.Verify(token)
+				if tt.revoked {
+					expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenRevoked)
+				} else if err != nil {
+					t.Errorf("expected error to be nil, returned '%v'", err)
+				}
+			})
+		}
+	}
+}
+
+func TestRevocationChecker_CheckedAfterTime(t *testing.T) {
+	list := jwt.NewMemoryRevocationList()
+	list.RevokeToken("token-id", time.Time{})
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.String(jwt.ID, "token-id"),
+		jwt.Time(jwt.Expires, time.Now().Add(-time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.Revocation = list
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenExpired)
+}
+
+func TestFileRevocationList_Persistence(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clock := jwt.ClockFunc(func() time.Time { return now })
+	afs := afero.NewMemMapFs()
+
+	list := jwt.NewFileRevocationListAFS(afs, "revoked.json")
+	list.Clock = clock
+
+	if err := list.RevokeToken("expiring", now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if err := list.RevokeSubject("subject", now); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	restarted := jwt.NewFileRevocationListAFS(afs, "revoked.json")
+	restarted.Clock = clock
+
+	if revoked, err := restarted.IsRevoked("expiring", "", time.Time{}); err != nil || !revoked {
+		t.Errorf("restarted.IsRevoked(expiring): returned '%v', '%v'", revoked, err)
+	}
+
+	if revoked, err := restarted.IsRevoked("", "subject", now.Add(-time.Second)); err != nil || !revoked {
+		t.Errorf("restarted.IsRevoked(subject): returned '%v', '%v'", revoked, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if err := restarted.RevokeToken("other", time.Time{}); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if revoked, err := restarted.IsRevoked("expiring", "", time.Time{}); err != nil || revoked {
+		t.Errorf("restarted.IsRevoked(expiring): returned '%v', '%v'", revoked, err)
+	}
+}
+
+func TestWithRevocationChecker(t *testing.T) {
+	_, err := jwt.NewVerifier(
+		jwt.WithAFS(createAfs()),
+		jwt.WithKeyFile("cert.pem"),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithRevocationChecker(nil),
+	)
+	expectErrMatch(t, "jwt.NewVerifier()", err, jwt.ErrInvalidOption)
+}
@@ -0,0 +1,309 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pascaljwt "github.com/pascaldekloe/jwt"
+)
+
+// Schema maps unregistered claim names to the `ClaimType` they are decoded as by a verifier,
+// claims that are not in the schema have their type inferred from the JSON value.
+//
+// Without a hint, strings are decoded as `StringType`, booleans as `BoolType`, integers as
+// `Int64Type` (or `Uint64Type` when out of range), other numbers as `Float64Type`, arrays of
+// strings as `StringsType` and everything else as `ReflectType`.
+//
+// With a hint, every value constructor in claims.go verifies back to an equal `Claim` of the
+// same type. Claims carrying a caller supplied interface (`Object`, `Array`, `Stringer`,
+// `NamedError` and `Reflect`) are decoded as their JSON representation.
+type Schema map[string]ClaimType
+
+// numericDateScale is the number of fractional digits in a NumericDate with nanosecond precision.
+const numericDateScale = 9
+
+// formatNumericDate returns the exact NumericDate for a time, `pascaljwt.NumericTime` is a float64
+// which can not hold nanosecond precision for current dates.
+func formatNumericDate(t time.Time) json.Number {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	if nsec == 0 {
+		return json.Number(strconv.FormatInt(sec, 10))
+	}
+
+	sign := ""
+	if sec < 0 {
+		sign, sec, nsec = "-", -(sec + 1), int64(time.Second)-nsec
+	}
+
+	frac := strings.TrimRight(fmt.Sprintf("%0*d", numericDateScale, nsec), "0")
+
+	return json.Number(sign + strconv.FormatInt(sec, 10) + "." + frac)
+}
+
+// parseNumericDate returns the time for a NumericDate, decimal values with up to nanosecond
+// precision are parsed exactly and anything else as a float64.
+func parseNumericDate(n json.Number) (time.Time, error) {
+	s := string(n)
+	digits := strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(digits, ".")
+
+	if strings.ContainsAny(s, "eE+") || len(frac) > numericDateScale {
+		f, err := n.Float64()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %w", ErrClaimFormatInvalid, err)
+		}
+
+		return numericDate(f), nil
+	}
+
+	sec, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrClaimFormatInvalid, err)
+	}
+
+	var nsec int64
+
+	if frac != "" {
+		nsec, err = strconv.ParseInt(frac+strings.Repeat("0", numericDateScale-len(frac)), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %w", ErrClaimFormatInvalid, err)
+		}
+	}
+
+	if s != digits {
+		sec, nsec = -sec, -nsec
+	}
+
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// rawClaims returns the top level JSON values of the token payload.
+func rawClaims(claims *pascaljwt.Claims) (map[string]json.RawMessage, error) {
+	raw := map[string]json.RawMessage{}
+
+	if len(claims.Raw) == 0 {
+		return raw, nil
+	}
+
+	if err := json.Unmarshal(claims.Raw, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+
+	return raw, nil
+}
+
+// rawTime returns the exact time of a registered NumericDate claim, falling back to the
+// parsed `pascaljwt.NumericTime`.
+func rawTime(raw map[string]json.RawMessage, key string, n *pascaljwt.NumericTime) time.Time {
+	if v, ok := raw[key]; ok {
+		if t, err := parseNumericDate(json.Number(bytes.TrimSpace(v))); err == nil {
+			return t
+		}
+	}
+
+	return n.Time()
+}
+
+// decodeClaim returns the claim for a raw JSON value using the schema hint when there is one.
+//
+//nolint:cyclop,funlen,gocognit // a case per type.
+func decodeClaim(key string, raw json.RawMessage, hint ClaimType) (Claim, error) {
+	invalid := fmt.Errorf("%w for %s", ErrInvalidClaimType, key)
+
+	switch hint {
+	case StringType, StringerType, ErrorType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return Claim{}, invalid
+		}
+
+		return String(key, s), nil
+	case StringsType:
+		var s []string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return Claim{}, invalid
+		}
+
+		return Strings(key, s), nil
+	case BoolType:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return Claim{}, invalid
+		}
+
+		return Bool(key, b), nil
+	case Int64Type, Int32Type, Int16Type, Int8Type:
+		i, err := strconv.ParseInt(string(raw), 10, intBitSize(hint))
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Claim{Key: key, Type: hint, Integer: i}, nil
+	case Uint64Type, Uint32Type, Uint16Type, Uint8Type, UintptrType:
+		u, err := strconv.ParseUint(string(raw), 10, intBitSize(hint))
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Claim{Key: key, Type: hint, Integer: int64(u)}, nil //nolint:gosec // stored as bits.
+	case Float64Type:
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Float64(key, f), nil
+	case Float32Type:
+		f, err := strconv.ParseFloat(string(raw), 32)
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Float32(key, float32(f)), nil
+	case Complex128Type, Complex64Type:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return Claim{}, invalid
+		}
+
+		if hint == Complex64Type {
+			c, err := strconv.ParseComplex(s, 64) //nolint:mnd // bit size.
+			if err != nil {
+				return Claim{}, invalid
+			}
+
+			return Complex64(key, complex64(c)), nil
+		}
+
+		c, err := strconv.ParseComplex(s, 128) //nolint:mnd // bit size.
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Complex128(key, c), nil
+	case DurationType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return Claim{}, invalid
+		}
+
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Duration(key, d), nil
+	case TimeType:
+		t, err := parseNumericDate(json.Number(raw))
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Time(key, t), nil
+	case BinaryType, ByteStringType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return Claim{}, invalid
+		}
+
+		if hint == ByteStringType {
+			return ByteString(key, []byte(s)), nil
+		}
+
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		if err != nil {
+			return Claim{}, invalid
+		}
+
+		return Binary(key, b), nil
+	case ArrayMarshalerType, ObjectMarshalerType, NamespaceType, ReflectType:
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return Claim{}, invalid
+		}
+
+		return Reflect(key, v), nil
+	case SkipType, UnknownType:
+	}
+
+	return inferClaim(key, raw)
+}
+
+// inferClaim returns the claim for a raw JSON value without a schema hint.
+func inferClaim(key string, raw json.RawMessage) (Claim, error) {
+	var v interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	if err := dec.Decode(&v); err != nil {
+		return Claim{}, fmt.Errorf("%w for %s: %w", ErrClaimFormatInvalid, key, err)
+	}
+
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return Int64(key, i), nil
+		}
+
+		if u, err := strconv.ParseUint(val.String(), 10, 64); err == nil {
+			return Uint64(key, u), nil
+		}
+
+		f, err := val.Float64()
+		if err != nil {
+			return Claim{}, fmt.Errorf("%w for %s: %w", ErrClaimFormatInvalid, key, err)
+		}
+
+		return Float64(key, f), nil
+	case []interface{}:
+		if s, ok := stringSlice(val); ok {
+			return Strings(key, s), nil
+		}
+	case string, bool:
+		return Any(key, val), nil
+	}
+
+	// nested values are decoded with encoding/json defaults.
+	var o interface{}
+	if err := json.Unmarshal(raw, &o); err != nil {
+		return Claim{}, fmt.Errorf("%w for %s: %w", ErrClaimFormatInvalid, key, err)
+	}
+
+	return Reflect(key, o), nil
+}
+
+// stringSlice returns the slice as a []string if every element is a string.
+func stringSlice(v []interface{}) ([]string, bool) {
+	o := make([]string, 0, len(v))
+
+	for _, e := range v {
+		s, ok := e.(string)
+		if !ok {
+			return nil, false
+		}
+
+		o = append(o, s)
+	}
+
+	return o, true
+}
+
+// intBitSize returns the bit size of an integer claim type.
+func intBitSize(t ClaimType) int {
+	switch t { //nolint:exhaustive // only integer types are passed.
+	case Int8Type, Uint8Type:
+		return 8 //nolint:mnd // bit size.
+	case Int16Type, Uint16Type:
+		return 16 //nolint:mnd // bit size.
+	case Int32Type, Uint32Type:
+		return 32 //nolint:mnd // bit size.
+	default:
+		return 64 //nolint:mnd // bit size.
+	}
+}
@@ -0,0 +1,212 @@
+package jwt_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func verifyRoundTrip(t *testing.T, schema jwt.Schema, claims ...jwt.Claim) jwt.VerifyResult {
+	t.Helper()
+
+	token, err := createSigner(t).SignClaims(append(claims, jwt.Strings(jwt.Audience, []string{"test-audience"}))...)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.Schema = schema
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	return result
+}
+
+func expectClaims(t *testing.T, result jwt.VerifyResult, claims ...jwt.Claim) bool {
+	t.Helper()
+
+	for _, claim := range claims {
+		if v := result.Claims[claim.Key]; !reflect.DeepEqual(v, claim) {
+			t.Errorf("result.Claims[%s]: expected '%#v', returned '%#v'", claim.Key, claim, v)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestVerify_RoundTrip_Property(t *testing.T) {
+	schema := jwt.Schema{
+		"string": jwt.StringType, "strings": jwt.StringsType, "bool": jwt.BoolType,
+		"int64": jwt.Int64Type, "int32": jwt.Int32Type, "int16": jwt.Int16Type, "int8": jwt.Int8Type,
+		"uint64": jwt.Uint64Type, "uint32": jwt.Uint32Type, "uint16": jwt.Uint16Type, "uint8": jwt.Uint8Type,
+		"uintptr": jwt.UintptrType, "float64": jwt.Float64Type, "float32": jwt.Float32Type,
+		"complex128": jwt.Complex128Type, "complex64": jwt.Complex64Type, "duration": jwt.DurationType,
+		"time": jwt.TimeType, "binary": jwt.BinaryType, "bytestring": jwt.ByteStringType,
+	}
+
+	property := func(
+		s string, ss []string, b bool, i64 int64, i32 int32, i16 int16, i8 int8,
+		u64 uint64, u32 uint32, u16 uint16, u8 uint8, f64 float64, f32 float32,
+		c128 complex128, d int64, ns int64, bin []byte,
+	) bool {
+		claims := []jwt.Claim{
+			jwt.String("string", s),
+			jwt.Strings("strings", ss),
+			jwt.Bool("bool", b),
+			jwt.Int64("int64", i64),
+			jwt.Int32("int32", i32),
+			jwt.Int16("int16", i16),
+			jwt.Int8("int8", i8),
+			jwt.Uint64("uint64", u64),
+			jwt.Uint32("uint32", u32),
+			jwt.Uint16("uint16", u16),
+			jwt.Uint8("uint8", u8),
+			jwt.Uintptr("uintptr", uintptr(u64)),
+			jwt.Float64("float64", f64),
+			jwt.Float32("float32", f32),
+			jwt.Complex128("complex128", c128),
+			jwt.Complex64("complex64", complex64(c128)),
+			jwt.Duration("duration", time.Duration(d)),
+			jwt.Time("time", time.Unix(0, ns)),
+			jwt.Binary("binary", bin),
+			jwt.ByteString("bytestring", []byte(s)),
+		}
+
+		return expectClaims(t, verifyRoundTrip(t, schema, claims...), claims...)
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerify_RoundTrip_RegisteredTimes_Property(t *testing.T) {
+	now := time.Now()
+
+	property := func(issued, expires uint32) bool {
+		claims := []jwt.Claim{
+			jwt.Time(jwt.Issued, now.Add(-time.Duration(issued))),
+			jwt.Time(jwt.NotBefore, now.Add(-time.Duration(issued))),
+			jwt.Time(jwt.Expires, now.Add(time.Hour+time.Duration(expires))),
+		}
+
+		result := verifyRoundTrip(t, nil, claims...)
+
+		return expectClaims(t, result, claims...) &&
+			result.IssuedAt.Equal(now.Add(-time.Duration(issued))) &&
+			result.Expires.Equal(now.Add(time.Hour+time.Duration(expires)))
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerify_RoundTrip_Extremes(t *testing.T) {
+	schema := jwt.Schema{
+		"int64": jwt.Int64Type, "uint64": jwt.Uint64Type, "float64": jwt.Float64Type,
+		"duration": jwt.DurationType, "before": jwt.TimeType, "after": jwt.TimeType,
+	}
+
+	for _, claims := range [][]jwt.Claim{
+		{
+			jwt.Int64("int64", math.MinInt64), jwt.Uint64("uint64", math.MaxUint64),
+			jwt.Float64("float64", math.SmallestNonzeroFloat64), jwt.Duration("duration", math.MinInt64),
+			jwt.Time("before", time.Unix(-1, 1)), jwt.Time("after", time.Unix(0, math.MaxInt64)),
+		},
+		{
+			jwt.Int64("int64", math.MaxInt64), jwt.Uint64("uint64", 0),
+			jwt.Float64("float64", math.Copysign(0, -1)), jwt.Duration("duration", math.MaxInt64),
+			jwt.Time("before", time.Unix(0, math.MinInt64)), jwt.Time("after", time.Unix(1, 999999999)),
+		},
+	} {
+		expectClaims(t, verifyRoundTrip(t, schema, claims...), claims...)
+	}
+}
+
+func TestVerify_InferredClaimTypes(t *testing.T) {
+	result := verifyRoundTrip(t, nil,
+		jwt.String("string", "value"),
+		jwt.Strings("strings", []string{"a", "b"}),
+		jwt.Bool("bool", true),
+		jwt.Int64("int64", -42),
+		jwt.Uint64("uint64", math.MaxUint64),
+		jwt.Float64("float64", 0.5),
+	)
+
+	expectClaims(t, result,
+		jwt.String("string", "value"),
+		jwt.Strings("strings", []string{"a", "b"}),
+		jwt.Bool("bool", true),
+		jwt.Int64("int64", -42),
+		jwt.Uint64("uint64", math.MaxUint64),
+		jwt.Float64("float64", 0.5),
+	)
+
+	custom := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+	result = verifyRoundTrip(t, nil, jwt.Time("custom", custom))
+
+	if claim := result.Claims["custom"]; claim.Type != jwt.Int64Type {
+		t.Errorf("result.Claims[custom].Type: expected '%d', returned '%d'", jwt.Int64Type, claim.Type)
+	}
+
+	if v, err := result.GetTime("custom"); err != nil || !v.Equal(custom) {
+		t.Errorf("GetTime(custom): returned '%v', '%v'", v, err)
+	}
+}
+
+func TestVerify_Schema_ShouldFail(t *testing.T) {
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Int64("small", 300),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.Schema = jwt.Schema{"small": jwt.Int8Type}
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrInvalidClaimType)
+}
+
+func TestWithSchema(t *testing.T) {
+	signer, err := jwt.NewSigner(jwt.WithAFS(createAfs()), jwt.WithKeyFile("key.pem"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier, err := jwt.NewVerifier(
+		jwt.WithAFS(createAfs()),
+		jwt.WithKeyFile("cert.pem"),
+		jwt.WithAudiences("test-audience"),
+		jwt.WithSchema(jwt.Schema{"login": jwt.TimeType}),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	login := time.Date(2024, time.March, 1, 10, 30, 0, 123456789, time.UTC)
+
+	token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}), jwt.Time("login", login))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectClaims(t, result, jwt.Time("login", login))
+}
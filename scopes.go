@@ -0,0 +1,126 @@
+package jwt
+
+import "strings"
+
+const (
+	// ScopeClaim is the OAuth 2.0 claim for the space-delimited scopes granted to a token.
+	ScopeClaim string = "scope"
+	// ScpClaim is the claim used by some issuers for the scopes granted to a token as an array.
+	ScpClaim string = "scp"
+	// RolesClaim is the claim for the roles granted to a token.
+	RolesClaim string = "roles"
+)
+
+// ScopeSlice is a helper type for taking a slice of scopes or roles and allowing it to be
+// checked, unlike `AudienceSlice` values are compared case sensitively as required by RFC 6749.
+type ScopeSlice []string
+
+// Has checks to see if a ScopeSlice contains a specific scope.
+func (s ScopeSlice) Has(scope string) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAny checks to see if any scope supplied is matched by any scope in the slice.
+func (s ScopeSlice) HasAny(scopes []string) bool {
+	for _, scope := range scopes {
+		if s.Has(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAll checks to see if all scopes supplied are matched by any scope in the slice.
+// if supplied scope list is empty, returns false.
+func (s ScopeSlice) HasAll(scopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !s.Has(scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Slice returns the ScopeSlice as the underlying string slice.
+func (s ScopeSlice) Slice() []string {
+	return s
+}
+
+// Scopes returns the scopes granted to the token from the "scope" and "scp" claims, each can
+// be a space-delimited string or an array of strings.
+func (r VerifyResult) Scopes() ScopeSlice {
+	return r.claimWords(ScopeClaim, ScpClaim)
+}
+
+// Roles returns the roles granted to the token from the "roles" claim, which can be a
+// space-delimited string or an array of strings.
+func (r VerifyResult) Roles() ScopeSlice {
+	return r.claimWords(RolesClaim)
+}
+
+// HasScope checks to see if the token was granted a specific scope.
+func (r VerifyResult) HasScope(scope string) bool {
+	return r.Scopes().Has(scope)
+}
+
+// HasAnyScope checks to see if the token was granted any of the scopes supplied.
+func (r VerifyResult) HasAnyScope(scopes ...string) bool {
+	return r.Scopes().HasAny(scopes)
+}
+
+// HasAllScopes checks to see if the token was granted all of the scopes supplied,
+// if supplied scope list is empty, returns false.
+func (r VerifyResult) HasAllScopes(scopes ...string) bool {
+	return r.Scopes().HasAll(scopes)
+}
+
+// HasRole checks to see if the token was granted a specific role.
+func (r VerifyResult) HasRole(role string) bool {
+	return r.Roles().Has(role)
+}
+
+// HasAnyRole checks to see if the token was granted any of the roles supplied.
+func (r VerifyResult) HasAnyRole(roles ...string) bool {
+	return r.Roles().HasAny(roles)
+}
+
+// HasAllRoles checks to see if the token was granted all of the roles supplied,
+// if supplied role list is empty, returns false.
+func (r VerifyResult) HasAllRoles(roles ...string) bool {
+	return r.Roles().HasAll(roles)
+}
+
+// claimWords returns the unique values of the claims, splitting strings on whitespace, claims
+// that are missing or not strings are ignored.
+func (r VerifyResult) claimWords(keys ...string) ScopeSlice {
+	o := ScopeSlice{}
+
+	for _, key := range keys {
+		values, err := r.GetStrings(key)
+		if err != nil {
+			continue
+		}
+
+		for _, value := range values {
+			for _, word := range strings.Fields(value) {
+				if !o.Has(word) {
+					o = append(o, word)
+				}
+			}
+		}
+	}
+
+	return o
+}
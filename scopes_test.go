@@ -0,0 +1,66 @@
+package jwt_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+func TestVerifyResult_Scopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims []jwt.Claim
+		expect jwt.ScopeSlice
+	}{
+		{"none", nil, jwt.ScopeSlice{}},
+		{"scope string", []jwt.Claim{jwt.String(jwt.ScopeClaim, "read  write")}, jwt.ScopeSlice{"read", "write"}},
+		{"scp array", []jwt.Claim{jwt.Strings(jwt.ScpClaim, []string{"read", "admin"})}, jwt.ScopeSlice{"read", "admin"}},
+		{"scp string", []jwt.Claim{jwt.String(jwt.ScpClaim, "read")}, jwt.ScopeSlice{"read"}},
+		{"combined", []jwt.Claim{
+			jwt.String(jwt.ScopeClaim, "read write"),
+			jwt.Strings(jwt.ScpClaim, []string{"write", "admin"}),
+		}, jwt.ScopeSlice{"read", "write", "admin"}},
+		{"not strings", []jwt.Claim{jwt.Bool(jwt.ScopeClaim, true)}, jwt.ScopeSlice{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createResult(t, tt.claims...)
+
+			if scopes := result.Scopes(); !reflect.DeepEqual(scopes, tt.expect) {
+				t.Errorf("result.Scopes(): expected '%v', returned '%v'", tt.expect, scopes)
+			}
+		})
+	}
+}
+
+func TestVerifyResult_HasScopes(t *testing.T) {
+	result := createResult(t, jwt.String(jwt.ScopeClaim, "read write"))
+
+	ExpectBool(t, "HasScope(read)", true, result.HasScope("read"))
+	ExpectBool(t, "HasScope(READ)", false, result.HasScope("READ"))
+	ExpectBool(t, "HasScope(admin)", false, result.HasScope("admin"))
+	ExpectBool(t, "HasAnyScope(admin, write)", true, result.HasAnyScope("admin", "write"))
+	ExpectBool(t, "HasAnyScope(admin)", false, result.HasAnyScope("admin"))
+	ExpectBool(t, "HasAnyScope()", false, result.HasAnyScope())
+	ExpectBool(t, "HasAllScopes(read, write)", true, result.HasAllScopes("read", "write"))
+	ExpectBool(t, "HasAllScopes(read, admin)", false, result.HasAllScopes("read", "admin"))
+	ExpectBool(t, "HasAllScopes()", false, result.HasAllScopes())
+}
+
+func TestVerifyResult_HasRoles(t *testing.T) {
+	result := createResult(t, jwt.Strings(jwt.RolesClaim, []string{"editor", "viewer"}))
+
+	ExpectBool(t, "HasRole(editor)", true, result.HasRole("editor"))
+	ExpectBool(t, "HasRole(admin)", false, result.HasRole("admin"))
+	ExpectBool(t, "HasAnyRole(admin, viewer)", true, result.HasAnyRole("admin", "viewer"))
+	ExpectBool(t, "HasAnyRole(admin)", false, result.HasAnyRole("admin"))
+	ExpectBool(t, "HasAllRoles(editor, viewer)", true, result.HasAllRoles("editor", "viewer"))
+	ExpectBool(t, "HasAllRoles(editor, admin)", false, result.HasAllRoles("editor", "admin"))
+	ExpectBool(t, "HasAllRoles()", false, result.HasAllRoles())
+
+	if scopes := result.Scopes(); len(scopes) != 0 {
+		t.Errorf("result.Scopes(): expected '[]', returned '%v'", scopes)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	pascaljwt "github.com/pascaldekloe/jwt"
+	"github.com/spf13/afero"
 )
 
 const (
@@ -15,6 +16,26 @@ const (
 	RS384 = pascaljwt.RS384
 	// RS512 RSASSA-PKCS1-v1_5 with SHA-512.
 	RS512 = pascaljwt.RS512
+	// PS256 RSASSA-PSS using SHA-256 and MGF1 with SHA-256.
+	PS256 = pascaljwt.PS256
+	// PS384 RSASSA-PSS using SHA-384 and MGF1 with SHA-384.
+	PS384 = pascaljwt.PS384
+	// PS512 RSASSA-PSS using SHA-512 and MGF1 with SHA-512.
+	PS512 = pascaljwt.PS512
+	// ES256 ECDSA using P-256 and SHA-256.
+	ES256 = pascaljwt.ES256
+	// ES384 ECDSA using P-384 and SHA-384.
+	ES384 = pascaljwt.ES384
+	// ES512 ECDSA using P-521 and SHA-512.
+	ES512 = pascaljwt.ES512
+	// EdDSA Edwards-curve Digital Signature Algorithm using Ed25519.
+	EdDSA = pascaljwt.EdDSA
+	// HS256 HMAC using SHA-256.
+	HS256 = pascaljwt.HS256
+	// HS384 HMAC using SHA-384.
+	HS384 = pascaljwt.HS384
+	// HS512 HMAC using SHA-512.
+	HS512 = pascaljwt.HS512
 )
 
 // // ErrAlgorithmUnknown signals an unsupported "alg" token (for the respective method).
@@ -26,15 +47,25 @@ type Signer interface {
 }
 
 // RSASigner implements the `Signer` interface and creates a token signed with RSA public/private keys.
+//
+// The Algorithm can be any of the RSASSA-PKCS1-v1_5 (RS256, RS384, RS512) or
+// RSASSA-PSS (PS256, PS384, PS512) algorithms, the same key is used for both.
+//
+// When Lifetime is set the issued, notbefore and expires times are stamped from Clock, and
+// DefaultClaims are added to every token. The claims passed to SignClaims override any of these.
 type RSASigner struct {
-	PrivateKey *rsa.PrivateKey
-	Issuer     string
-	Algorithm  string
+	PrivateKey    *rsa.PrivateKey
+	Issuer        string
+	Algorithm     string
+	KeyID         string
+	Clock         Clock
+	Lifetime      time.Duration
+	DefaultClaims []Claim
 }
 
-// NewRSASignerFromFile returns an `RSASigner` initialized with the RSA Private Key supplied.
+// NewRSASignerFromFile returns an `RSASigner` initialized with the PKCS1 or PKCS8 RSA Private Key supplied.
 func NewRSASignerFromFile(filename string) (Signer, error) {
-	privateKey, err := ParsePKCS1PrivateKeyFromFile(filename)
+	privateKey, err := ParseRSAPrivateKeyFromFile(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -45,14 +76,66 @@ func NewRSASignerFromFile(filename string) (Signer, error) {
 	}, nil
 }
 
+// NewRSASignerFromEncryptedFile returns an `RSASigner` initialized with the encrypted RSA Private Key
+// supplied, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewRSASignerFromEncryptedFile(filename string, passphrase PassphraseFunc) (Signer, error) {
+	return NewRSASignerFromEncryptedFileAFS(afero.NewOsFs(), filename, passphrase)
+}
+
+// NewRSASignerFromEncryptedFileAFS returns an `RSASigner` initialized with the encrypted RSA Private Key
+// supplied with a supplied `afero.Fs`, the passphrase is requested from the `PassphraseFunc` and wiped after use.
+func NewRSASignerFromEncryptedFileAFS(afs afero.Fs, filename string, passphrase PassphraseFunc) (Signer, error) {
+	key, err := ParseEncryptedPrivateKeyFromFileAFS(afs, filename, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return &RSASigner{
+		PrivateKey: privateKey,
+		Algorithm:  pascaljwt.RS256,
+	}, nil
+}
+
+// NewRSASignerFromJWK returns an `RSASigner` initialized with the RSA Private Key in the JWK supplied,
+// the algorithm is taken from the JWK and defaults to RS256.
+func NewRSASignerFromJWK(jwk JWK) (Signer, error) {
+	privateKey, ok := jwk.Key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	alg := jwk.Algorithm
+	if alg == "" {
+		alg = RS256
+	}
+
+	return &RSASigner{
+		PrivateKey: privateKey,
+		Algorithm:  alg,
+		KeyID:      jwk.KeyID,
+	}, nil
+}
+
+// JWK returns the private key of the signer as a JWK with the key ID set to the signers KeyID,
+// or the RFC 7638 thumbprint if it is not set.
+func (r *RSASigner) JWK() (JWK, error) {
+	return newSignerJWK(r.PrivateKey, r.KeyID, r.Algorithm)
+}
+
 // SignClaims takes a list of claims and produces a signed token.
 func (r *RSASigner) SignClaims(claims ...Claim) ([]byte, error) {
-	tokenClaims, err := ConstructClaimsFromSlice(
-		append(
-			[]Claim{String("iss", r.Issuer)},
-			claims...,
-		)...,
-	)
+	tokenClaims, err := constructSignerClaims(signerPolicy{
+		issuer:   r.Issuer,
+		keyID:    r.KeyID,
+		clock:    r.Clock,
+		claims:   r.DefaultClaims,
+		lifetime: r.Lifetime,
+	}, claims)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +148,58 @@ func (r *RSASigner) SignClaims(claims ...Claim) ([]byte, error) {
 	return token, nil
 }
 
+// signerPolicy is the token construction configuration of a signer.
+type signerPolicy struct {
+	issuer   string
+	keyID    string
+	clock    Clock
+	claims   []Claim
+	lifetime time.Duration
+}
+
+// constructSignerClaims prepends the signers issuer, the issued, notbefore and expires times from
+// the signers clock when the signer has a lifetime and the signers default claims to the supplied
+// claims, so they can be overridden, and returns the prepared `pascaljwt.Claims` with the key ID
+// for the token header.
+func constructSignerClaims(policy signerPolicy, claims []Claim) (*pascaljwt.Claims, error) {
+	prepared := make([]Claim, 0, 4+len(policy.claims)+len(claims)) //nolint:mnd // registered defaults.
+	prepared = append(prepared, String("iss", policy.issuer))
+
+	if policy.lifetime > 0 {
+		now := clockNow(policy.clock)
+		prepared = append(prepared,
+			Time(Issued, now), Time(NotBefore, now), Time(Expires, now.Add(policy.lifetime)))
+	}
+
+	prepared = append(prepared, policy.claims...)
+
+	tokenClaims, err := ConstructClaimsFromSlice(append(prepared, claims...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the registered times are signed from the exact NumericDate values in the claim set as
+	// `pascaljwt.NumericTime` is a float64 and would round them.
+	tokenClaims.Expires, tokenClaims.NotBefore, tokenClaims.Issued = nil, nil, nil
+	tokenClaims.KeyID = policy.keyID
+
+	return tokenClaims, nil
+}
+
+// newSignerJWK returns a JWK for a signers private key, using the key ID if it is set.
+func newSignerJWK(key interface{}, keyID, alg string) (JWK, error) {
+	jwk, err := NewJWK(key, KeyUseSignature, alg)
+	if err != nil {
+		return jwk, err
+	}
+
+	if keyID != "" {
+		jwk.KeyID = keyID
+	}
+
+	return jwk, nil
+}
+
 // Sign takes a signer, subject, audience, online status, notBefore and expiry and produces a signed token.
 func Sign(
 	signer Signer,
@@ -2,6 +2,7 @@ package jwt_test
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -433,3 +434,109 @@ func TestJWTSigner_ShouldSucceed_OnlyLastKeyUsed(t *testing.T) {
 	expectTimeZero(t, "result.NotBefore", result.NotBefore)
 	expectTimeZero(t, "result.Expires", result.Expires)
 }
+
+func TestJWTSigner_Lifetime(t *testing.T) {
+	issued := time.Now().Truncate(time.Second)
+
+	signer := createSigner(t).(*jwt.RSASigner)
+	signer.Clock = jwt.FixedClock(issued)
+	signer.Lifetime = time.Hour
+	signer.DefaultClaims = []jwt.Claim{jwt.Strings(jwt.Audience, []string{"test-audience"})}
+
+	verifier := createVerifier(t)
+
+	token, err := signer.SignClaims(jwt.String(jwt.Subject, "subject"))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectTimeVaguelyEqual(t, "result.IssuedAt", result.IssuedAt, issued)
+	expectTimeVaguelyEqual(t, "result.NotBefore", result.NotBefore, issued)
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, issued.Add(time.Hour))
+
+	expTime := issued.Add(10 * time.Minute)
+
+	token, err = signer.SignClaims(
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Time(jwt.Expires, expTime),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err = verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectTimeVaguelyEqual(t, "result.NotBefore", result.NotBefore, issued)
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, expTime)
+}
+
+func TestJWTSigner_WithLifetime(t *testing.T) {
+	signer, err := jwt.NewSigner(
+		jwt.WithKeyBytes([]byte(ecPrivateKey)),
+		jwt.WithLifetime(time.Minute),
+		jwt.WithDefaultClaims(jwt.Strings(jwt.Audience, []string{"test-audience"})),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := signer.SignClaims()
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createECDSAVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, time.Now().Add(time.Minute))
+
+	if _, err := jwt.NewSigner(jwt.WithKeyBytes([]byte(ecPrivateKey)), jwt.WithLifetime(0)); err == nil {
+		t.Error("expected error to not be nil")
+	}
+}
+
+func TestJWTSigner_DefaultClaims_Namespace(t *testing.T) {
+	issued := time.Now().Truncate(time.Second)
+
+	signer := createSigner(t).(*jwt.RSASigner)
+	signer.Clock = jwt.FixedClock(issued)
+	signer.Lifetime = time.Hour
+	signer.DefaultClaims = []jwt.Claim{jwt.Namespace("ctx"), jwt.String("tenant", "test-tenant")}
+
+	token, err := signer.SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Time(jwt.Expires, issued.Add(10*time.Minute)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectString(t, "result.Subject", result.Subject, "subject")
+	expectStringElement(t, "result.Audience", result.Audience, "test-audience")
+	expectTimeVaguelyEqual(t, "result.Expires", result.Expires, issued.Add(10*time.Minute))
+
+	ctx, err := result.GetObject("ctx")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if !reflect.DeepEqual(ctx, map[string]interface{}{"tenant": "test-tenant"}) {
+		t.Errorf("result.Claims[ctx]: expected 'map[tenant:test-tenant]', returned '%v'", ctx)
+	}
+}
@@ -0,0 +1,58 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// readJSONFile decodes the JSON file into v.
+func readJSONFile(afs afero.Fs, filename string, v interface{}) error {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return fmt.Errorf("unable to read file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to decode file: %w", err)
+	}
+
+	return nil
+}
+
+// writeJSONFile encodes v to a temporary file (readable only by the owner) that replaces the
+// named file, so a failed write leaves the previous contents in place.
+func writeJSONFile(afs afero.Fs, filename string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to encode file: %w", err)
+	}
+
+	f, err := afero.TempFile(afs, filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = afs.Remove(f.Name())
+
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		_ = afs.Remove(f.Name())
+
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+
+	if err := afs.Rename(f.Name(), filename); err != nil {
+		_ = afs.Remove(f.Name())
+
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,549 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidStruct is returned when a value supplied for decoding or encoding claims is not a
+// struct, or a non-nil pointer to a struct for decoding.
+var ErrInvalidStruct = errors.New("invalid struct for claims")
+
+// structTag is the struct tag used to name the claim for a field.
+const structTag = "jwt"
+
+//nolint:gochecknoglobals // reflected types used for comparison.
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Decode copies the claims into the struct pointed to by v, fields are matched to claims using
+// the `jwt:"name"` struct tag, or the field name when there is no tag, and fields tagged
+// `jwt:"-"` are skipped.
+//
+// Nested structs are decoded from JSON objects, `time.Time` fields from NumericDate claims and
+// `time.Duration` fields from duration strings or a number of seconds.
+func (r VerifyResult) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrInvalidStruct, v)
+	}
+
+	values := make(map[string]interface{}, len(r.Claims))
+	for key, claim := range r.Claims {
+		values[key] = claimValue(claim)
+	}
+
+	return decodeStruct(rv.Elem(), values, "")
+}
+
+// ClaimsFromStruct returns the claims for the fields of the struct (or pointer to a struct)
+// supplied using the same field naming as `VerifyResult.Decode`, fields with the `omitempty`
+// tag option are skipped when they hold the zero value.
+func ClaimsFromStruct(v interface{}) ([]Claim, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrInvalidStruct, v)
+	}
+
+	claims := []Claim{}
+
+	err := walkStruct(rv, true, func(name string, field reflect.Value) error {
+		claim, err := fieldClaim(name, field)
+		if err != nil {
+			return err
+		}
+
+		claims = append(claims, claim)
+
+		return nil
+	})
+
+	return claims, err
+}
+
+// walkStruct calls fn with the claim name and value of each exported field, flattening
+// untagged embedded structs, zero value fields tagged `omitempty` are skipped when encoding.
+func walkStruct(rv reflect.Value, encoding bool, fn func(name string, field reflect.Value) error) error {
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := fieldName(sf)
+		if skip {
+			continue
+		}
+
+		field := rv.Field(i)
+
+		if sf.Anonymous && sf.Tag.Get(structTag) == "" && field.Kind() == reflect.Struct {
+			if err := walkStruct(field, encoding, fn); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if encoding && omitEmpty && field.IsZero() {
+			continue
+		}
+
+		if err := fn(name, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldName returns the claim name and tag options for a struct field.
+func fieldName(sf reflect.StructField) (string, bool, bool) {
+	tag := sf.Tag.Get(structTag)
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = sf.Name
+	}
+
+	return name, opts == "omitempty", false
+}
+
+// fieldClaim returns the claim for a struct field value.
+//
+//nolint:cyclop // a case per kind.
+func fieldClaim(name string, field reflect.Value) (Claim, error) {
+	switch {
+	case field.Type() == timeType:
+		t, _ := field.Interface().(time.Time)
+
+		return Time(name, t), nil
+	case field.Type() == durationType:
+		return Duration(name, time.Duration(field.Int())), nil
+	}
+
+	switch field.Kind() { //nolint:exhaustive // remaining kinds are encoded by value.
+	case reflect.String:
+		return String(name, field.String()), nil
+	case reflect.Bool:
+		return Bool(name, field.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int64(name, field.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Uint64(name, field.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return Float64(name, field.Float()), nil
+	case reflect.Slice:
+		switch field.Type().Elem().Kind() { //nolint:exhaustive // other slices are encoded by value.
+		case reflect.String:
+			return Strings(name, sliceStrings(field)), nil
+		case reflect.Uint8:
+			return Binary(name, field.Bytes()), nil
+		}
+	}
+
+	value, err := encodeValue(field)
+	if err != nil {
+		return Claim{}, fmt.Errorf("%w for %s", err, name)
+	}
+
+	return Reflect(name, value), nil
+}
+
+// sliceStrings returns a slice of a string kind as a []string.
+func sliceStrings(field reflect.Value) []string {
+	if field.IsNil() {
+		return nil
+	}
+
+	o := make([]string, field.Len())
+	for i := range o {
+		o[i] = field.Index(i).String()
+	}
+
+	return o
+}
+
+// encodeValue returns the JSON representation of a value using the same rules as `ClaimsFromStruct`.
+//
+//nolint:cyclop // a case per kind.
+func encodeValue(value reflect.Value) (interface{}, error) {
+	switch {
+	case value.Type() == timeType:
+		t, _ := value.Interface().(time.Time)
+
+		return formatNumericDate(t), nil
+	case value.Type() == durationType:
+		return time.Duration(value.Int()).String(), nil
+	}
+
+	switch value.Kind() { //nolint:exhaustive // remaining kinds are passed to encoding/json.
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return encodeValue(value.Elem())
+	case reflect.Struct:
+		o := map[string]interface{}{}
+
+		err := walkStruct(value, true, func(name string, field reflect.Value) error {
+			v, err := encodeValue(field)
+			o[name] = v
+
+			return err
+		})
+
+		return o, err
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.RawURLEncoding.EncodeToString(value.Bytes()), nil
+		}
+
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		fallthrough
+	case reflect.Array:
+		o := make([]interface{}, value.Len())
+
+		for i := range o {
+			v, err := encodeValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			o[i] = v
+		}
+
+		return o, nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, ErrUnsupportedClaimType
+		}
+
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		o := make(map[string]interface{}, value.Len())
+
+		for iter := value.MapRange(); iter.Next(); {
+			v, err := encodeValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+
+			o[iter.Key().String()] = v
+		}
+
+		return o, nil
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return nil, ErrUnsupportedClaimType
+	default:
+		return value.Interface(), nil
+	}
+}
+
+// claimValue returns the Go value held by a claim.
+func claimValue(claim Claim) interface{} {
+	switch claim.Type { //nolint:exhaustive // other types hold their value in Interface.
+	case StringType:
+		return claim.String
+	case TimeType:
+		t, _ := claim.Time()
+
+		return t
+	case DurationType:
+		return time.Duration(claim.Integer)
+	case Int8Type, Int16Type, Int32Type, Int64Type:
+		return claim.Integer
+	case Uint8Type, Uint16Type, Uint32Type, Uint64Type, UintptrType:
+		return uint64(claim.Integer) //nolint:gosec // stored as bits.
+	case Float32Type, Float64Type:
+		f, _ := claimNumber(claim)
+
+		return f
+	default:
+		return claim.Interface
+	}
+}
+
+// decodeStruct sets the fields of the struct from the values keyed by claim name.
+func decodeStruct(rv reflect.Value, values map[string]interface{}, path string) error {
+	return walkStruct(rv, false, func(name string, field reflect.Value) error {
+		value, ok := values[name]
+		if !ok || value == nil {
+			return nil
+		}
+
+		return decodeValue(field, value, path+name)
+	})
+}
+
+// decodeValue sets the destination from a decoded claim value, converting JSON types where needed,
+// a null value leaves the destination unchanged.
+//
+//nolint:cyclop,funlen,gocognit // a case per kind.
+func decodeValue(dst reflect.Value, src interface{}, path string) error {
+	if src == nil {
+		return nil
+	}
+
+	invalid := fmt.Errorf("%w for %s", ErrInvalidClaimType, path)
+
+	switch {
+	case dst.Type() == timeType:
+		t, ok := decodeTime(src)
+		if !ok {
+			return invalid
+		}
+
+		dst.Set(reflect.ValueOf(t))
+
+		return nil
+	case dst.Type() == durationType:
+		d, ok := decodeDuration(src)
+		if !ok {
+			return invalid
+		}
+
+		dst.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch dst.Kind() { //nolint:exhaustive // unsupported kinds return an error.
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return decodeValue(dst.Elem(), src, path)
+	case reflect.Interface:
+		v := reflect.ValueOf(src)
+		if !v.Type().AssignableTo(dst.Type()) {
+			return invalid
+		}
+
+		dst.Set(v)
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return invalid
+		}
+
+		dst.SetString(s)
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return invalid
+		}
+
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := decodeInt(src)
+		if !ok || dst.OverflowInt(i) {
+			return invalid
+		}
+
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, ok := decodeUint(src)
+		if !ok || dst.OverflowUint(u) {
+			return invalid
+		}
+
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, ok := decodeNumber(src)
+		if !ok || dst.OverflowFloat(f) {
+			return invalid
+		}
+
+		dst.SetFloat(f)
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			if b, ok := src.([]byte); ok {
+				dst.SetBytes(b)
+
+				return nil
+			}
+
+			s, ok := src.(string)
+			if !ok {
+				return invalid
+			}
+
+			b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+			if err != nil {
+				return invalid
+			}
+
+			dst.SetBytes(b)
+
+			return nil
+		}
+
+		sv := reflect.ValueOf(src)
+		if sv.Kind() != reflect.Slice {
+			return invalid
+		}
+
+		o := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+
+		for i := range sv.Len() {
+			if err := decodeValue(o.Index(i), sv.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+		dst.Set(o)
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return invalid
+		}
+
+		o := reflect.MakeMapWithSize(dst.Type(), len(m))
+
+		for k, v := range m {
+			ev := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeValue(ev, v, path+"."+k); err != nil {
+				return err
+			}
+
+			o.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), ev)
+		}
+
+		dst.Set(o)
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return invalid
+		}
+
+		return decodeStruct(dst, m, path+".")
+	default:
+		return invalid
+	}
+
+	return nil
+}
+
+// decodeNumber returns the numeric value of a decoded claim value.
+func decodeNumber(src interface{}) (float64, bool) {
+	switch v := src.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// decodeInt returns the exact integer value of a decoded claim value, floats are only accepted
+// when they are whole numbers in range.
+func decodeInt(src interface{}) (int64, bool) {
+	switch v := src.(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return i, true
+		}
+	}
+
+	f, ok := decodeNumber(src)
+	if !ok || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+
+	return int64(f), true
+}
+
+// decodeUint returns the exact unsigned integer value of a decoded claim value, floats are only
+// accepted when they are whole numbers in range.
+func decodeUint(src interface{}) (uint64, bool) {
+	switch v := src.(type) {
+	case uint64:
+		return v, true
+	case int64:
+		return uint64(v), v >= 0
+	case json.Number:
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u, true
+		}
+	}
+
+	f, ok := decodeNumber(src)
+	if !ok || f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, false
+	}
+
+	return uint64(f), true
+}
+
+// decodeTime returns the time from a time, NumericDate or RFC 3339 string claim value.
+func decodeTime(src interface{}) (time.Time, bool) {
+	switch v := src.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+
+		return t, err == nil
+	}
+
+	if f, ok := decodeNumber(src); ok {
+		return numericDate(f), true
+	}
+
+	return time.Time{}, false
+}
+
+// decodeDuration returns the duration from a duration, duration string or seconds claim value.
+func decodeDuration(src interface{}) (time.Duration, bool) {
+	switch v := src.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		d, err := time.ParseDuration(v)
+
+		return d, err == nil
+	}
+
+	if f, ok := decodeNumber(src); ok {
+		return time.Duration(math.Round(f * float64(time.Second))), true
+	}
+
+	return 0, false
+}
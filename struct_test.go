@@ -0,0 +1,204 @@
+package jwt_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/na4ma4/jwt/v2"
+)
+
+type testAddress struct {
+	Street string `jwt:"street"`
+	Zip    int    `jwt:"zip"`
+}
+
+type testTokenClaims struct {
+	Subject  string            `jwt:"sub"`
+	Expires  time.Time         `jwt:"exp"`
+	Audience []string          `jwt:"aud"`
+	Name     string            `jwt:"name"`
+	Count    int               `jwt:"count"`
+	Ratio    float64           `jwt:"ratio"`
+	Admin    bool              `jwt:"admin"`
+	Groups   []string          `jwt:"groups"`
+	Ports    []uint16          `jwt:"ports"`
+	Login    time.Time         `jwt:"login"`
+	TTL      time.Duration     `jwt:"ttl"`
+	Address  testAddress       `jwt:"address"`
+	Previous []testAddress     `jwt:"previous,omitempty"`
+	Labels   map[string]string `jwt:"labels,omitempty"`
+	Nickname *string           `jwt:"nickname,omitempty"`
+	Ignored  string            `jwt:"-"`
+}
+
+func TestClaimsFromStruct_RoundTrip(t *testing.T) {
+	nickname := "tess"
+	input := testTokenClaims{
+		Subject:  "subject",
+		Expires:  time.Now().Add(time.Hour).Truncate(time.Second),
+		Audience: []string{"test-audience"},
+		Name:     "test user",
+		Count:    42,
+		Ratio:    0.5,
+		Admin:    true,
+		Groups:   []string{"a", "b"},
+		Ports:    []uint16{80, 443},
+		Login:    time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC),
+		TTL:      90 * time.Minute,
+		Address:  testAddress{Street: "1 Test Street", Zip: 3000},
+		Previous: []testAddress{{Street: "2 Old Road", Zip: 2000}},
+		Labels:   map[string]string{"team": "core"},
+		Nickname: &nickname,
+		Ignored:  "ignored",
+	}
+
+	claims, err := jwt.ClaimsFromStruct(input)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	token, err := createSigner(t).SignClaims(claims...)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	var output testTokenClaims
+	if err := result.Decode(&output); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	input.Ignored = ""
+
+	if !output.Expires.Equal(input.Expires) || !output.Login.Equal(input.Login) {
+		t.Errorf("Decode(): times expected '%s', '%s', returned '%s', '%s'",
+			input.Expires, input.Login, output.Expires, output.Login)
+	}
+
+	output.Expires, output.Login = input.Expires, input.Login
+
+	if !reflect.DeepEqual(output, input) {
+		t.Errorf("Decode(): expected '%+v', returned '%+v'", input, output)
+	}
+}
+
+func TestClaimsFromStruct_OmitEmpty(t *testing.T) {
+	claims, err := jwt.ClaimsFromStruct(&testTokenClaims{})
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	for _, claim := range claims {
+		switch claim.Key {
+		case "previous", "labels", "nickname", "Ignored":
+			t.Errorf("ClaimsFromStruct(): unexpected claim '%s'", claim.Key)
+		}
+	}
+}
+
+func TestVerifyResult_Decode_ShouldFail(t *testing.T) {
+	result := createResult(t,
+		jwt.String("name", "test user"),
+		jwt.Int64("count", 300),
+	)
+
+	var notPointer testTokenClaims
+	expectErrMatch(t, "result.Decode()", result.Decode(notPointer), jwt.ErrInvalidStruct)
+
+	var wrongType struct {
+		Name int `jwt:"name"`
+	}
+	expectErrMatch(t, "result.Decode()", result.Decode(&wrongType), jwt.ErrInvalidClaimType)
+
+	var overflow struct {
+		Count int8 `jwt:"count"`
+	}
+	expectErrMatch(t, "result.Decode()", result.Decode(&overflow), jwt.ErrInvalidClaimType)
+
+	_, err := jwt.ClaimsFromStruct("not a struct")
+	expectErrMatch(t, "jwt.ClaimsFromStruct()", err, jwt.ErrInvalidStruct)
+}
+
+func TestVerifyResult_Decode_ExactIntegers(t *testing.T) {
+	result := createResult(t,
+		jwt.Int64("int", 1<<60+1),
+		jwt.Uint64("uint", 1<<63+1),
+		jwt.Int64("negative", -1),
+		jwt.Float64("fraction", 1.5),
+		jwt.Float64("whole", 3),
+	)
+
+	var output struct {
+		Int   int64  `jwt:"int"`
+		Uint  uint64 `jwt:"uint"`
+		Whole int    `jwt:"whole"`
+	}
+
+	if err := result.Decode(&output); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if output.Int != 1<<60+1 {
+		t.Errorf("output.Int: expected '%d', returned '%d'", int64(1<<60+1), output.Int)
+	}
+
+	if output.Uint != 1<<63+1 {
+		t.Errorf("output.Uint: expected '%d', returned '%d'", uint64(1<<63+1), output.Uint)
+	}
+
+	if output.Whole != 3 {
+		t.Errorf("output.Whole: expected '3', returned '%d'", output.Whole)
+	}
+
+	var overflow struct {
+		Int int64 `jwt:"uint"`
+	}
+	expectErrMatch(t, "result.Decode()", result.Decode(&overflow), jwt.ErrInvalidClaimType)
+
+	var negative struct {
+		Uint uint `jwt:"negative"`
+	}
+	expectErrMatch(t, "result.Decode()", result.Decode(&negative), jwt.ErrInvalidClaimType)
+
+	var fraction struct {
+		Int int `jwt:"fraction"`
+	}
+	expectErrMatch(t, "result.Decode()", result.Decode(&fraction), jwt.ErrInvalidClaimType)
+}
+
+func TestVerifyResult_Decode_NullElements(t *testing.T) {
+	result := createResult(t,
+		jwt.Any("meta", map[string]interface{}{"a": nil, "b": "x"}),
+		jwt.Any("list", []interface{}{"x", nil}),
+		jwt.Any("names", []interface{}{nil, "y"}),
+	)
+
+	var output struct {
+		Meta  map[string]interface{} `jwt:"meta"`
+		List  []interface{}          `jwt:"list"`
+		Names []string               `jwt:"names"`
+	}
+
+	if err := result.Decode(&output); err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if v, ok := output.Meta["a"]; !ok || v != nil {
+		t.Errorf("output.Meta[a]: expected nil, returned '%v' (present: %t)", v, ok)
+	}
+
+	expectString(t, "output.Meta[b]", output.Meta["b"].(string), "x")
+
+	if !reflect.DeepEqual(output.List, []interface{}{"x", nil}) {
+		t.Errorf("output.List: expected '[x <nil>]', returned '%v'", output.List)
+	}
+
+	if !reflect.DeepEqual(output.Names, []string{"", "y"}) {
+		t.Errorf("output.Names: expected '[ y]', returned '%q'", output.Names)
+	}
+}
@@ -1,9 +1,11 @@
 package jwt
 
 import (
+	"bytes"
 	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
@@ -13,17 +15,27 @@ import (
 // ErrTokenInvalidAudience is the error returned when an audience does not match the token.
 var ErrTokenInvalidAudience = errors.New("invalid token audience")
 
-// ErrTokenTimeNotValid is the general error returned when a token is outside the NotBefore or Expires times.
+// ErrTokenTimeNotValid is the general error returned when a token is outside the NotBefore or Expires times,
+// it matches both `ErrTokenExpired` and `ErrTokenNotYetValid` failures.
 var ErrTokenTimeNotValid = errors.New("token time is not valid")
 
+// ErrTokenInvalidIssuer is the error returned when the issuer does not match the token.
+var ErrTokenInvalidIssuer = errors.New("invalid token issuer")
+
+// ErrAlgorithmNotAllowed is the error returned when the token algorithm is not in the verifiers allowed algorithms.
+var ErrAlgorithmNotAllowed = errors.New("token algorithm is not allowed")
+
 // VerifyResult returns the information about the token verification.
 type VerifyResult struct {
 	ID             string
+	KeyID          string
 	IsOnline       bool
+	Issuer         string
 	Subject        string
 	Audience       AudienceSlice
 	ClaimAudiences AudienceSlice
 	Fingerprint    string
+	IssuedAt       time.Time
 	NotBefore      time.Time
 	Expires        time.Time
 	Claims         map[string]Claim
@@ -35,18 +47,60 @@ type Verifier interface {
 	Verify(token []byte) (VerifyResult, error)
 }
 
+// Check is an additional check of a verified token, returning an error rejects the token.
+type Check func(result VerifyResult) error
+
+// CheckVerifier is a `Verifier` that can run additional checks on a token before its ID is
+// recorded by a `ReplayStore`, so a token rejected by a check is not used up.
+type CheckVerifier interface {
+	Verifier
+	VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error)
+}
+
 // RSAVerifier implements the `Verifier` interface and tests a token signed with RSA public/private keys.
+//
+// Issuer and Issuers are the accepted token issuers, when both are empty the issuer is not checked.
+//
+// Leeway is the clock skew tolerated when checking the "nbf", "exp" and "iat" times, which
+// are compared to the time from Clock, or the system time when Clock is nil.
+//
+// RequiredClaims are the registered or custom claims that must be present in the token.
+// MaxLifetime limits the time between the "iat" (or "nbf") and "exp" claims, and MaxAge limits
+// the time since the "iat" claim, the claims used are required when the limits are set.
+//
+// Schema is the type hints used to decode custom claims into `VerifyResult.Claims`.
+//
+// Revocation is consulted once the signature and claims have been checked, tokens it reports as
+// revoked are rejected with `ErrTokenRevoked`.
+//
+// ReplayStore records the "jti" of each verified token until it expires and rejects tokens that
+// have already been used, the "jti" and "exp" claims are required when it is set.
+//
+// Algorithms restricts the accepted token algorithms, when empty all RSASSA-PKCS1-v1_5
+// and RSASSA-PSS algorithms are accepted.
 type RSAVerifier struct {
-	PublicKey *rsa.PublicKey
-	Issuer    string
-	Audiences []string
-	// Algorithms []string
+	PublicKey      *rsa.PublicKey
+	Issuer         string
+	Issuers        []string
+	Audiences      []string
+	Leeway         time.Duration
+	Clock          Clock
+	RequiredClaims []string
+	MaxLifetime    time.Duration
+	MaxAge         time.Duration
+	Schema         Schema
+	Revocation     RevocationChecker
+	ReplayStore    ReplayStore
+	Algorithms     []string
 }
 
+// rsaAlgorithms are the algorithms accepted by the `RSAVerifier` when none are specified.
+var rsaAlgorithms = []string{RS256, RS384, RS512, PS256, PS384, PS512}
+
 // NewRSAVerifierFromFile returns an `RSAVerifier` initialized with the RSA Public Key
 // supplied and an audience for token verification.
 func NewRSAVerifierFromFile(audiences []string, filename string) (Verifier, error) {
-	publicKey, err := ParsePKCS1PublicKeyFromFile(filename)
+	publicKey, err := ParseRSAPublicKeyFromFile(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -54,65 +108,204 @@ func NewRSAVerifierFromFile(audiences []string, filename string) (Verifier, erro
 	return &RSAVerifier{
 		Audiences: audiences,
 		PublicKey: publicKey,
-		// Algorithms: []string{pascaljwt.RS256, pascaljwt.RS384, pascaljwt.RS512},
 	}, nil
 }
 
-func (v *RSAVerifier) getClaimMapFromClaims(claims *pascaljwt.Claims) (map[string]Claim, error) {
-	c := make(map[string]Claim)
+// NewRSAVerifierFromJWK returns an `RSAVerifier` initialized with the RSA Public Key in the JWK
+// supplied and an audience for token verification, if the JWK specifies an algorithm only that
+// algorithm is accepted.
+func NewRSAVerifierFromJWK(audiences []string, jwk JWK) (Verifier, error) {
+	publicJWK, err := jwk.Public()
+	if err != nil {
+		return nil, err
+	}
 
-	if claims.Subject != "" {
-		c[Subject] = String(Subject, claims.Subject)
+	publicKey, ok := publicJWK.Key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
 	}
 
-	if claims.NotBefore != nil {
-		c[NotBefore] = Time(NotBefore, claims.NotBefore.Time())
+	return &RSAVerifier{
+		Audiences:  audiences,
+		PublicKey:  publicKey,
+		Algorithms: jwkAlgorithms(jwk),
+	}, nil
+}
+
+// JWK returns the public key of the verifier as a JWK with the key ID set to the RFC 7638 thumbprint.
+func (v *RSAVerifier) JWK() (JWK, error) {
+	return NewJWK(v.PublicKey, KeyUseSignature, singleAlgorithm(v.Algorithms))
+}
+
+// Verify takes the token and checks it's signature against the RSA public key,
+// and the audience, notbefore and expires validity.
+func (v *RSAVerifier) Verify(token []byte) (VerifyResult, error) {
+	return v.VerifyWithChecks(token)
+}
+
+// VerifyWithChecks verifies the token in the same way as Verify, running the checks supplied
+// before the token ID is recorded by the ReplayStore.
+func (v *RSAVerifier) VerifyWithChecks(token []byte, checks ...Check) (VerifyResult, error) {
+	if _, err := checkAlgorithm(token, v.Algorithms, rsaAlgorithms); err != nil {
+		return VerifyResult{}, err
 	}
 
-	if claims.Expires != nil {
-		c[Expires] = Time(Expires, claims.Expires.Time())
+	claims, err := pascaljwt.RSACheck(token, v.PublicKey)
+	if err != nil {
+		return VerifyResult{}, checkError(err)
 	}
 
-	if claims.Audiences != nil {
-		c[Audience] = Strings(Audience, claims.Audiences)
+	return verifyClaims(claims, claimsPolicy{
+		issuers:    acceptedIssuers(v.Issuer, v.Issuers),
+		audiences:  v.Audiences,
+		leeway:     v.Leeway,
+		clock:      v.Clock,
+		required:   v.RequiredClaims,
+		lifetime:   v.MaxLifetime,
+		maxAge:     v.MaxAge,
+		schema:     v.Schema,
+		revocation: v.Revocation,
+		replay:     v.ReplayStore,
+		checks:     checks,
+	})
+}
+
+// jwkAlgorithms returns the algorithm of the JWK as an allowed algorithm list, or nil if not set.
+func jwkAlgorithms(jwk JWK) []string {
+	if jwk.Algorithm == "" {
+		return nil
 	}
 
-	for k, v := range claims.Set {
-		switch k {
-		case NotBefore, Expires, Issued:
-			if f, ok := claims.Number(k); ok {
-				t := time.Unix(0, int64(f*float64(time.Second)))
-				c[k] = Time(k, t)
-			} else {
-				return c, ErrInvalidClaimType
-			}
-		default:
-			c[k] = Any(k, v)
+	return []string{jwk.Algorithm}
+}
+
+// singleAlgorithm returns the algorithm if only one is allowed, otherwise it returns an empty string.
+func singleAlgorithm(algorithms []string) string {
+	if len(algorithms) == 1 {
+		return algorithms[0]
+	}
+
+	return ""
+}
+
+// tokenHeader is the JOSE header of a token.
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// parseTokenHeader decodes the JOSE header of a token without checking the signature.
+func parseTokenHeader(token []byte) (tokenHeader, error) {
+	header := tokenHeader{}
+
+	encoded := token
+	if i := bytes.IndexByte(token, '.'); i >= 0 {
+		encoded = token[:i]
+	}
+
+	data := make([]byte, base64.RawURLEncoding.DecodedLen(len(encoded)))
+
+	n, err := base64.RawURLEncoding.Decode(data, encoded)
+	if err != nil {
+		return header, &VerificationError{Reason: ErrMalformedToken, Claim: "header", Err: err}
+	}
+
+	if err := json.Unmarshal(data[:n], &header); err != nil {
+		return header, &VerificationError{Reason: ErrMalformedToken, Claim: "header", Err: err}
+	}
+
+	return header, nil
+}
+
+// checkAlgorithm returns the token header, or `ErrAlgorithmNotAllowed` if the token algorithm
+// is not in the allowed list, or in the defaults list when no allowed algorithms are specified.
+func checkAlgorithm(token []byte, allowed, defaults []string) (tokenHeader, error) {
+	header, err := parseTokenHeader(token)
+	if err != nil {
+		return header, err
+	}
+
+	if len(allowed) == 0 {
+		allowed = defaults
+	}
+
+	for _, alg := range allowed {
+		if alg == header.Algorithm {
+			return header, nil
 		}
 	}
 
-	return c, nil
+	return header, &VerificationError{
+		Reason: ErrAlgorithmNotAllowed,
+		Claim:  "alg",
+		Err:    pascaljwt.AlgError(header.Algorithm),
+	}
 }
 
-// Verify takes the token and checks it's signature against the RSA public key,
-// and the audience, notbefore and expires validity.
-func (v *RSAVerifier) Verify(token []byte) (VerifyResult, error) {
-	checkTime := time.Now()
+// checkError returns a `VerificationError` for an error from a signature check.
+func checkError(err error) error {
+	var algErr pascaljwt.AlgError
+
+	switch {
+	case errors.Is(err, pascaljwt.ErrSigMiss):
+		return &VerificationError{Reason: ErrSignatureInvalid, Err: err}
+	case errors.As(err, &algErr):
+		return &VerificationError{Reason: ErrAlgorithmNotAllowed, Claim: "alg", Err: err}
+	default:
+		return &VerificationError{Reason: ErrMalformedToken, Err: err}
+	}
+}
+
+// claimsPolicy is the claim validation configuration of a verifier.
+type claimsPolicy struct {
+	issuers    []string
+	audiences  []string
+	leeway     time.Duration
+	clock      Clock
+	required   []string
+	lifetime   time.Duration
+	maxAge     time.Duration
+	schema     Schema
+	revocation RevocationChecker
+	replay     ReplayStore
+	checks     []Check
+}
+
+// acceptedIssuers combines the single and multiple issuer fields of a verifier.
+func acceptedIssuers(issuer string, issuers []string) []string {
+	if issuer == "" {
+		return issuers
+	}
+
+	return append([]string{issuer}, issuers...)
+}
+
+// verifyClaims checks the issuer, audience, notbefore and expires validity of claims that
+// have already passed a signature check and returns the populated `VerifyResult`.
+func verifyClaims(claims *pascaljwt.Claims, policy claimsPolicy) (VerifyResult, error) {
+	checkTime := clockNow(policy.clock)
 	result := VerifyResult{}
 
-	claims, err := pascaljwt.RSACheck(token, v.PublicKey)
-	if err != nil {
-		return result, fmt.Errorf("jwt failed check: %w", err)
+	if !hasIssuer(claims.Issuer, policy.issuers) {
+		return result, &VerificationError{Reason: ErrTokenInvalidIssuer, Claim: Issuer}
 	}
 
-	if !v.hasAudience(claims.Audiences) {
-		return result, ErrTokenInvalidAudience
+	if !hasAudience(claims.Audiences, policy.audiences) {
+		return result, &VerificationError{Reason: ErrTokenInvalidAudience, Claim: Audience}
 	}
 
-	acceptedAudiences := v.matchingAudiences(claims.Audiences)
+	acceptedAudiences := matchingAudiences(claims.Audiences, policy.audiences)
 
-	if !claims.Valid(checkTime) {
-		return result, ErrTokenTimeNotValid
+	if err := checkTemporal(claims, checkTime, policy.leeway); err != nil {
+		return result, err
+	}
+
+	if err := checkRequired(claims, policy.required); err != nil {
+		return result, err
+	}
+
+	if err := checkLifetime(claims, checkTime, policy); err != nil {
+		return result, err
 	}
 
 	online := false
@@ -129,31 +322,229 @@ func (v *RSAVerifier) Verify(token []byte) (VerifyResult, error) {
 		Subject:        claims.Subject,
 		IsOnline:       online,
 		ID:             claims.ID,
+		KeyID:          claims.KeyID,
+		Issuer:         claims.Issuer,
 		Audience:       acceptedAudiences,
 		ClaimAudiences: claims.Audiences,
 		Fingerprint:    fingerprint,
+		IssuedAt:       time.Time{},
 		NotBefore:      time.Time{},
 		Expires:        time.Time{},
 	}
 
+	var err error
+
+	result.Claims, err = getClaimMapFromClaims(claims, policy.schema)
+	if err != nil {
+		return result, err
+	}
+
+	if claim, ok := result.Claims[Issued]; ok {
+		t, _ := claim.Time()
+		result.IssuedAt = t.UTC()
+	}
+
+	if claim, ok := result.Claims[NotBefore]; ok {
+		t, _ := claim.Time()
+		result.NotBefore = t.UTC()
+	}
+
+	if claim, ok := result.Claims[Expires]; ok {
+		t, _ := claim.Time()
+		result.Expires = t.UTC()
+	}
+
+	if err := checkRevocation(result, policy); err != nil {
+		return VerifyResult{}, err
+	}
+
+	for _, check := range policy.checks {
+		if err := check(result); err != nil {
+			return VerifyResult{}, err
+		}
+	}
+
+	if err := checkReplay(claims, policy); err != nil {
+		return VerifyResult{}, err
+	}
+
+	return result, nil
+}
+
+// checkTemporal checks the "iat", "nbf" and "exp" times of the claims against the check time,
+// allowing for the leeway in either direction.
+func checkTemporal(claims *pascaljwt.Claims, checkTime time.Time, leeway time.Duration) error {
+	earliest := checkTime.Add(-leeway)
+	latest := checkTime.Add(leeway)
+
+	temporalError := func(reason error, claim string, claimTime *pascaljwt.NumericTime) error {
+		return &VerificationError{
+			Reason:    reason,
+			Claim:     claim,
+			Time:      claimTime.Time(),
+			CheckTime: checkTime,
+			Leeway:    leeway,
+		}
+	}
+
+	if claims.Issued != nil && claims.Issued.Time().After(latest) {
+		return temporalError(ErrTokenNotYetValid, Issued, claims.Issued)
+	}
+
+	if claims.NotBefore != nil && claims.NotBefore.Time().After(latest) {
+		return temporalError(ErrTokenNotYetValid, NotBefore, claims.NotBefore)
+	}
+
+	if claims.Expires != nil && !claims.Expires.Time().After(earliest) {
+		return temporalError(ErrTokenExpired, Expires, claims.Expires)
+	}
+
+	return nil
+}
+
+// checkRequired checks that each of the required claims is present.
+func checkRequired(claims *pascaljwt.Claims, required []string) error {
+	for _, name := range required {
+		if !hasClaim(claims, name) {
+			return &VerificationError{Reason: ErrTokenMissingClaim, Claim: name}
+		}
+	}
+
+	return nil
+}
+
+// hasClaim returns true if the registered or custom claim is present.
+func hasClaim(claims *pascaljwt.Claims, name string) bool {
+	switch name {
+	case Issuer:
+		return claims.Issuer != ""
+	case Subject:
+		return claims.Subject != ""
+	case Audience:
+		return len(claims.Audiences) > 0
+	case Expires:
+		return claims.Expires != nil
+	case NotBefore:
+		return claims.NotBefore != nil
+	case Issued:
+		return claims.Issued != nil
+	case ID:
+		return claims.ID != ""
+	default:
+		_, ok := claims.Set[name]
+
+		return ok
+	}
+}
+
+// checkLifetime checks the lifetime of the token and the age since it was issued do not exceed the policy.
+func checkLifetime(claims *pascaljwt.Claims, checkTime time.Time, policy claimsPolicy) error {
+	if policy.lifetime > 0 {
+		if claims.Expires == nil {
+			return &VerificationError{Reason: ErrTokenMissingClaim, Claim: Expires}
+		}
+
+		start, claim := claims.Issued, Issued
+		if start == nil || (claims.NotBefore != nil && claims.NotBefore.Time().Before(start.Time())) {
+			start, claim = claims.NotBefore, NotBefore
+		}
+
+		if start == nil {
+			return &VerificationError{Reason: ErrTokenMissingClaim, Claim: Issued}
+		}
+
+		if claims.Expires.Time().Sub(start.Time()) > policy.lifetime {
+			return &VerificationError{
+				Reason:    ErrTokenLifetimeExceeded,
+				Claim:     claim,
+				Time:      start.Time(),
+				CheckTime: claims.Expires.Time(),
+			}
+		}
+	}
+
+	if policy.maxAge > 0 {
+		if claims.Issued == nil {
+			return &VerificationError{Reason: ErrTokenMissingClaim, Claim: Issued}
+		}
+
+		if checkTime.Sub(claims.Issued.Time()) > policy.maxAge+policy.leeway {
+			return &VerificationError{
+				Reason:    ErrTokenTooOld,
+				Claim:     Issued,
+				Time:      claims.Issued.Time(),
+				CheckTime: checkTime,
+				Leeway:    policy.leeway,
+			}
+		}
+	}
+
+	return nil
+}
+
+func getClaimMapFromClaims(claims *pascaljwt.Claims, schema Schema) (map[string]Claim, error) {
+	c := make(map[string]Claim)
+
+	raw, err := rawClaims(claims)
+	if err != nil {
+		return c, err
+	}
+
+	if claims.Issuer != "" {
+		c[Issuer] = String(Issuer, claims.Issuer)
+	}
+
+	if claims.Subject != "" {
+		c[Subject] = String(Subject, claims.Subject)
+	}
+
+	if claims.ID != "" {
+		c[ID] = String(ID, claims.ID)
+	}
+
+	if claims.Issued != nil {
+		c[Issued] = Time(Issued, rawTime(raw, Issued, claims.Issued))
+	}
+
 	if claims.NotBefore != nil {
-		result.NotBefore = claims.NotBefore.Time()
+		c[NotBefore] = Time(NotBefore, rawTime(raw, NotBefore, claims.NotBefore))
 	}
 
 	if claims.Expires != nil {
-		result.Expires = claims.Expires.Time()
+		c[Expires] = Time(Expires, rawTime(raw, Expires, claims.Expires))
 	}
 
-	result.Claims, err = v.getClaimMapFromClaims(claims)
-	// result.Claims[Audience] = String(Audience, v.Audiences)
+	if claims.Audiences != nil {
+		c[Audience] = Strings(Audience, claims.Audiences)
+	}
+
+	for k, v := range claims.Set {
+		switch k {
+		case NotBefore, Expires, Issued:
+			// numeric times are moved out of the set, anything left is not a valid time.
+			return c, &VerificationError{Reason: ErrInvalidClaimType, Claim: k}
+		}
 
-	return result, err
+		value, ok := raw[k]
+		if !ok {
+			c[k] = Any(k, v)
+
+			continue
+		}
+
+		c[k], err = decodeClaim(k, value, schema[k])
+		if err != nil {
+			return c, err
+		}
+	}
+
+	return c, nil
 }
 
-func (v *RSAVerifier) matchingAudiences(claimAudiences []string) []string {
+func matchingAudiences(claimAudiences, audiences []string) []string {
 	o := []string{}
 
-	for _, v := range v.Audiences {
+	for _, v := range audiences {
 		for _, c := range claimAudiences {
 			if strings.EqualFold(v, c) {
 				o = append(o, c)
@@ -164,6 +555,21 @@ func (v *RSAVerifier) matchingAudiences(claimAudiences []string) []string {
 	return o
 }
 
-func (v *RSAVerifier) hasAudience(claimAudiences AudienceSlice) bool {
-	return claimAudiences.HasAny(v.Audiences)
+func hasAudience(claimAudiences AudienceSlice, audiences []string) bool {
+	return claimAudiences.HasAny(audiences)
+}
+
+// hasIssuer returns true if no issuers are configured or the token issuer is one of them.
+func hasIssuer(claimIssuer string, issuers []string) bool {
+	if len(issuers) == 0 {
+		return true
+	}
+
+	for _, issuer := range issuers {
+		if issuer == claimIssuer {
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,6 +1,7 @@
 package jwt_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -207,6 +208,125 @@ func TestJWTVerifier_ShouldSucceed_AlgorithmRS512(t *testing.T) {
 	expectString(t, "result.Subject", result.Subject, "test-subject")
 }
 
+func TestJWTVerifier_ShouldSucceed_AlgorithmPSS(t *testing.T) {
+	verifier := createVerifier(t)
+
+	privateKey, err := jwt.ParsePKCS1PrivateKeyFromFileAFS(createAfs(), "key.pem")
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	for _, alg := range []string{jwt.PS256, jwt.PS384, jwt.PS512} {
+		t.Run(alg, func(t *testing.T) {
+			algSigner := &jwt.RSASigner{
+				Algorithm:  alg,
+				PrivateKey: privateKey,
+			}
+			token, err := jwt.Sign(
+				algSigner,
+				[]string{"test-audience"},
+				"test-subject",
+				false,
+				time.Now(), time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			if len(token) == 0 {
+				t.Error("expected token not to be empty")
+			}
+
+			result, err := verifier.Verify(token)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			expectBool(t, "result.IsOnline", result.IsOnline, false)
+			expectString(t, "result.Subject", result.Subject, "test-subject")
+		})
+	}
+}
+
+func TestJWTVerifier_AlgorithmAllowList(t *testing.T) {
+	publicKey, err := jwt.ParsePKCS1PublicKeyFromFileAFS(createAfs(), "cert.pem")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	privateKey, err := jwt.ParsePKCS1PrivateKeyFromFileAFS(createAfs(), "key.pem")
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name       string
+		algorithms []string
+		alg        string
+		allowed    bool
+	}{
+		{"default accepts RS256", nil, jwt.RS256, true},
+		{"default accepts PS512", nil, jwt.PS512, true},
+		{"RS512 only accepts RS512", []string{jwt.RS512}, jwt.RS512, true},
+		{"RS512 only rejects RS256", []string{jwt.RS512}, jwt.RS256, false},
+		{"RS512 only rejects PS512", []string{jwt.RS512}, jwt.PS512, false},
+		{"PSS only rejects RS384", []string{jwt.PS256, jwt.PS384, jwt.PS512}, jwt.RS384, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &jwt.RSAVerifier{
+				PublicKey:  publicKey,
+				Audiences:  []string{"test-audience"},
+				Algorithms: tt.algorithms,
+			}
+			algSigner := &jwt.RSASigner{
+				Algorithm:  tt.alg,
+				PrivateKey: privateKey,
+			}
+
+			token, err := jwt.Sign(
+				algSigner,
+				[]string{"test-audience"},
+				"test-subject",
+				false,
+				time.Now(), time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+
+			result, err := verifier.Verify(token)
+			if tt.allowed {
+				if err != nil {
+					t.Errorf("expected error to be nil, returned '%v'", err)
+				}
+				expectString(t, "result.Subject", result.Subject, "test-subject")
+			} else {
+				expectErrMatch(t, "jwt.ErrAlgorithmNotAllowed", err, jwt.ErrAlgorithmNotAllowed)
+				expectStringEmpty(t, "result.Subject", result.Subject)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_ShouldFail_AlgorithmNotRSA(t *testing.T) {
+	verifier := createVerifier(t)
+
+	token, err := jwt.Sign(
+		createHMACSigner(t),
+		[]string{"test-audience"},
+		"test-subject",
+		false,
+		time.Now(), time.Now().Add(time.Hour),
+	)
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := verifier.Verify(token)
+	expectErrMatch(t, "jwt.ErrAlgorithmNotAllowed", err, jwt.ErrAlgorithmNotAllowed)
+	expectStringEmpty(t, "result.Subject", result.Subject)
+}
+
 func TestJWTVerifier_ShouldFail_AlgorithmHS256(t *testing.T) {
 	// signer := createSigner(t)
 	// verifier := createVerifier(t)
@@ -395,3 +515,232 @@ func TestJWTVerifier_ValidJWTInvalidSigning(t *testing.T) {
 		t.Errorf("%s: expected not to equal '%s', returned '%s'", "result.Subject", "test-subject", result.Subject)
 	}
 }
+
+func TestJWTVerifier_Issuer(t *testing.T) {
+	signer := createSigner(t)
+	signer.(*jwt.RSASigner).Issuer = "https://issuer.example.com"
+
+	token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name    string
+		issuer  string
+		issuers []string
+		valid   bool
+	}{
+		{"no issuer configured", "", nil, true},
+		{"issuer matches", "https://issuer.example.com", nil, true},
+		{"issuer mismatch", "https://other.example.com", nil, false},
+		{"one of issuers", "", []string{"https://other.example.com", "https://issuer.example.com"}, true},
+		{"none of issuers", "", []string{"https://other.example.com", "https://another.example.com"}, false},
+		{"issuer and issuers", "https://other.example.com", []string{"https://issuer.example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.Issuer = tt.issuer
+			verifier.Issuers = tt.issuers
+
+			result, err := verifier.Verify(token)
+			if !tt.valid {
+				expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenInvalidIssuer)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			expectString(t, "result.Issuer", result.Issuer, "https://issuer.example.com")
+			expectClaim(t, "result.Claims[iss]", result.Claims, jwt.String(jwt.Issuer, "https://issuer.example.com"))
+		})
+	}
+}
+
+func TestJWTVerifier_ShouldFail_MissingIssuer(t *testing.T) {
+	token, err := createSigner(t).SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	verifier := createVerifier(t).(*jwt.RSAVerifier)
+	verifier.Issuer = "https://issuer.example.com"
+
+	_, err = verifier.Verify(token)
+	expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenInvalidIssuer)
+}
+
+func TestJWTVerifier_Leeway(t *testing.T) {
+	issued := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	token, err := createSigner(t).SignClaims(
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Issued, issued),
+		jwt.Time(jwt.NotBefore, issued),
+		jwt.Time(jwt.Expires, issued.Add(time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		leeway time.Duration
+		valid  bool
+	}{
+		{"within validity", issued.Add(time.Minute), 0, true},
+		{"before issued", issued.Add(-time.Second), 0, false},
+		{"before issued within leeway", issued.Add(-time.Second), time.Minute, true},
+		{"before issued outside leeway", issued.Add(-2 * time.Minute), time.Minute, false},
+		{"at expiry", issued.Add(time.Hour), 0, false},
+		{"after expiry within leeway", issued.Add(time.Hour + 30*time.Second), time.Minute, true},
+		{"after expiry outside leeway", issued.Add(time.Hour + 2*time.Minute), time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.Clock = jwt.FixedClock(tt.now)
+			verifier.Leeway = tt.leeway
+
+			result, err := verifier.Verify(token)
+			if !tt.valid {
+				expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenTimeNotValid)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected error to be nil, returned '%v'", err)
+			}
+
+			expectTimeVaguelyEqual(t, "result.IssuedAt", result.IssuedAt, issued)
+		})
+	}
+}
+
+func TestJWTSigner_DoesNotStampIssued(t *testing.T) {
+	signer := createSigner(t)
+	signer.(*jwt.RSASigner).Clock = jwt.FixedClock(time.Now().Add(-time.Hour))
+
+	token, err := signer.SignClaims(jwt.Strings(jwt.Audience, []string{"test-audience"}))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	result, err := createVerifier(t).Verify(token)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expectTimeZero(t, "result.IssuedAt", result.IssuedAt)
+}
+
+func TestJWTVerifier_RequiredClaims(t *testing.T) {
+	token, err := createSigner(t).SignClaims(
+		jwt.String(jwt.Subject, "subject"),
+		jwt.Strings(jwt.Audience, []string{"test-audience"}),
+		jwt.Time(jwt.Issued, time.Now()),
+		jwt.String("role", "admin"),
+	)
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		missing  string
+	}{
+		{"present", []string{jwt.Subject, jwt.Issued, jwt.ID, jwt.Audience, "role"}, ""},
+		{"missing exp", []string{jwt.Subject, jwt.Expires}, jwt.Expires},
+		{"missing custom", []string{"tenant"}, "tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.RequiredClaims = tt.required
+
+			_, err := verifier.Verify(token)
+			if tt.missing == "" {
+				if err != nil {
+					t.Errorf("expected error to be nil, returned '%v'", err)
+				}
+
+				return
+			}
+
+			expectErrMatch(t, "verifier.Verify()", err, jwt.ErrTokenMissingClaim)
+
+			var verr *jwt.VerificationError
+			if errors.As(err, &verr) {
+				expectString(t, "verr.Claim", verr.Claim, tt.missing)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_MaxLifetimeAndAge(t *testing.T) {
+	issued := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	signer := createSigner(t)
+
+	sign := func(claims ...jwt.Claim) []byte {
+		token, err := signer.SignClaims(append(claims,
+			jwt.Strings(jwt.Audience, []string{"test-audience"}),
+			jwt.Time(jwt.Issued, issued),
+		)...)
+		if err != nil {
+			t.Fatalf("expected error to be nil, returned '%v'", err)
+		}
+
+		return token
+	}
+
+	hour := sign(jwt.Time(jwt.Expires, issued.Add(time.Hour)))
+	day := sign(jwt.Time(jwt.Expires, issued.Add(24*time.Hour)))
+	immortal := sign()
+
+	tests := []struct {
+		name     string
+		token    []byte
+		now      time.Time
+		lifetime time.Duration
+		maxAge   time.Duration
+		expect   error
+	}{
+		{"lifetime within maximum", hour, issued, 2 * time.Hour, 0, nil},
+		{"lifetime exceeds maximum", day, issued, 2 * time.Hour, 0, jwt.ErrTokenLifetimeExceeded},
+		{"lifetime without expiry", immortal, issued, 2 * time.Hour, 0, jwt.ErrTokenMissingClaim},
+		{"age within maximum", day, issued.Add(time.Minute), 0, time.Hour, nil},
+		{"age exceeds maximum", day, issued.Add(2 * time.Hour), 0, time.Hour, jwt.ErrTokenTooOld},
+		{"immortal token age exceeds maximum", immortal, issued.Add(2 * time.Hour), 0, time.Hour, jwt.ErrTokenTooOld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := createVerifier(t).(*jwt.RSAVerifier)
+			verifier.Clock = jwt.FixedClock(tt.now)
+			verifier.MaxLifetime = tt.lifetime
+			verifier.MaxAge = tt.maxAge
+
+			_, err := verifier.Verify(tt.token)
+			if tt.expect == nil {
+				if err != nil {
+					t.Errorf("expected error to be nil, returned '%v'", err)
+				}
+
+				return
+			}
+
+			expectErrMatch(t, "verifier.Verify()", err, tt.expect)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package jwt
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -13,6 +16,29 @@ import (
 // ErrExtractPublicKey is returned if the public key failed to get extracted from a valid certificate file.
 var ErrExtractPublicKey = errors.New("unable to extract public key")
 
+// ErrExtractPrivateKey is returned if the private key is not of the expected type.
+var ErrExtractPrivateKey = errors.New("unable to extract private key")
+
+// ErrDecodePEM is returned if the supplied data does not contain a PEM block.
+var ErrDecodePEM = errors.New("unable to decode PEM block")
+
+// ErrUnsupportedPEMBlock is returned if the PEM block type is not a supported key or certificate type.
+var ErrUnsupportedPEMBlock = errors.New("unsupported PEM block type")
+
+// ErrUnsupportedKeyType is returned if a parsed key is not an RSA, ECDSA or Ed25519 key.
+var ErrUnsupportedKeyType = errors.New("unsupported key type")
+
+// PEM block types understood by `ParsePublicKey` and `ParsePrivateKey`.
+const (
+	pemTypeCertificate   = "CERTIFICATE"
+	pemTypePublicKey     = "PUBLIC KEY"
+	pemTypeRSAPublicKey  = "RSA PUBLIC KEY"
+	pemTypePrivateKey    = "PRIVATE KEY"
+	pemTypeRSAPrivateKey = "RSA PRIVATE KEY"
+	pemTypeECPrivateKey  = "EC PRIVATE KEY"
+	pemTypeECParameters  = "EC PARAMETERS"
+)
+
 // ParsePKCS1PublicKeyFromFile parses a PKCS1 Public Certificate from a PEM file.
 func ParsePKCS1PublicKeyFromFile(filename string) (*rsa.PublicKey, error) {
 	return ParsePKCS1PublicKeyFromFileAFS(afero.NewOsFs(), filename)
@@ -31,6 +57,9 @@ func ParsePKCS1PublicKeyFromFileAFS(afs afero.Fs, filename string) (*rsa.PublicK
 // ParsePKCS1PublicKey parses a PKCS1 Public Certificate from a byte slice containing a PEM certificate.
 func ParsePKCS1PublicKey(data []byte) (*rsa.PublicKey, error) {
 	publicPem, _ := pem.Decode(data)
+	if publicPem == nil {
+		return nil, ErrDecodePEM
+	}
 
 	publicCert, err := x509.ParseCertificate(publicPem.Bytes)
 	if err != nil {
@@ -63,6 +92,9 @@ func ParsePKCS1PrivateKeyFromFileAFS(afs afero.Fs, filename string) (*rsa.Privat
 // ParsePKCS1PrivateKey parses a PKCS1 Private Key from a byte slice containing an RSA key in PEM format.
 func ParsePKCS1PrivateKey(data []byte) (*rsa.PrivateKey, error) {
 	privatePem, _ := pem.Decode(data)
+	if privatePem == nil {
+		return nil, ErrDecodePEM
+	}
 
 	privateKey, err := x509.ParsePKCS1PrivateKey(privatePem.Bytes)
 	if err != nil {
@@ -71,3 +103,318 @@ func ParsePKCS1PrivateKey(data []byte) (*rsa.PrivateKey, error) {
 
 	return privateKey, nil
 }
+
+// ParsePublicKeyFromFile parses a Public Key or Certificate from a PEM file.
+func ParsePublicKeyFromFile(filename string) (crypto.PublicKey, error) {
+	return ParsePublicKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParsePublicKeyFromFileAFS parses a Public Key or Certificate from a PEM file with a supplied `afero.Fs`.
+func ParsePublicKeyFromFileAFS(afs afero.Fs, filename string) (crypto.PublicKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public key: %w", err)
+	}
+
+	return ParsePublicKey(data)
+}
+
+// ParsePublicKey parses a Public Key from a byte slice containing a PEM block, the block type is
+// detected and can be a "CERTIFICATE", a PKIX "PUBLIC KEY" or a PKCS1 "RSA PUBLIC KEY".
+//
+// The returned key is one of `*rsa.PublicKey`, `*ecdsa.PublicKey` or `ed25519.PublicKey`.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	publicPem, _ := pem.Decode(data)
+	if publicPem == nil {
+		return nil, ErrDecodePEM
+	}
+
+	var key crypto.PublicKey
+
+	switch publicPem.Type {
+	case pemTypeCertificate:
+		publicCert, err := x509.ParseCertificate(publicPem.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate: %w", err)
+		}
+
+		key = publicCert.PublicKey
+	case pemTypePublicKey:
+		publicKey, err := x509.ParsePKIXPublicKey(publicPem.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse public key: %w", err)
+		}
+
+		key = publicKey
+	case pemTypeRSAPublicKey:
+		publicKey, err := x509.ParsePKCS1PublicKey(publicPem.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse public key: %w", err)
+		}
+
+		key = publicKey
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPEMBlock, publicPem.Type)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKeyType, key)
+	}
+}
+
+// ParsePrivateKeyFromFile parses a Private Key from a PEM file.
+func ParsePrivateKeyFromFile(filename string) (crypto.Signer, error) {
+	return ParsePrivateKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParsePrivateKeyFromFileAFS parses a Private Key from a PEM file with a supplied `afero.Fs`.
+func ParsePrivateKeyFromFileAFS(afs afero.Fs, filename string) (crypto.Signer, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+
+	return ParsePrivateKey(data)
+}
+
+// ParsePrivateKey parses a Private Key from a byte slice containing a PEM block, the block type is
+// detected and can be a PKCS8 "PRIVATE KEY", a PKCS1 "RSA PRIVATE KEY" or a SEC 1 "EC PRIVATE KEY".
+// Any "EC PARAMETERS" blocks before the key (as written by `openssl ecparam -genkey`) are skipped.
+//
+// The returned key is one of `*rsa.PrivateKey`, `*ecdsa.PrivateKey` or `ed25519.PrivateKey`.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	for {
+		privatePem, rest := pem.Decode(data)
+		if privatePem == nil {
+			return nil, ErrDecodePEM
+		}
+
+		if privatePem.Type != pemTypeECParameters {
+			return parsePrivateKeyBlock(privatePem.Type, privatePem.Bytes)
+		}
+
+		data = rest
+	}
+}
+
+// parsePrivateKeyBlock parses the DER encoded private key of the supplied PEM block type.
+func parsePrivateKeyBlock(blockType string, der []byte) (crypto.Signer, error) {
+	var (
+		key interface{}
+		err error
+	)
+
+	switch blockType {
+	case pemTypePrivateKey:
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case pemTypeRSAPrivateKey:
+		key, err = x509.ParsePKCS1PrivateKey(der)
+	case pemTypeECPrivateKey:
+		key, err = x509.ParseECPrivateKey(der)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPEMBlock, blockType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKeyType, key)
+	}
+}
+
+// ParseRSAPublicKeyFromFile parses an RSA Public Key or Certificate from a PEM file.
+func ParseRSAPublicKeyFromFile(filename string) (*rsa.PublicKey, error) {
+	return ParseRSAPublicKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseRSAPublicKeyFromFileAFS parses an RSA Public Key or Certificate from a PEM file with a supplied `afero.Fs`.
+func ParseRSAPublicKeyFromFileAFS(afs afero.Fs, filename string) (*rsa.PublicKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read public key: %w", err)
+	}
+
+	return ParseRSAPublicKey(data)
+}
+
+// ParseRSAPublicKey parses an RSA Public Key from a byte slice containing any PEM block supported
+// by `ParsePublicKey`.
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	key, err := ParsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
+	}
+
+	return publicKey, nil
+}
+
+// ParseRSAPrivateKeyFromFile parses an RSA Private Key from a PEM file.
+func ParseRSAPrivateKeyFromFile(filename string) (*rsa.PrivateKey, error) {
+	return ParseRSAPrivateKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseRSAPrivateKeyFromFileAFS parses an RSA Private Key from a PEM file with a supplied `afero.Fs`.
+func ParseRSAPrivateKeyFromFileAFS(afs afero.Fs, filename string) (*rsa.PrivateKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+
+	return ParseRSAPrivateKey(data)
+}
+
+// ParseRSAPrivateKey parses an RSA Private Key from a byte slice containing either a PKCS1
+// or PKCS8 key in PEM format.
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return privateKey, nil
+}
+
+// ParseECPublicKeyFromFile parses an ECDSA Public Key or Certificate from a PEM file.
+func ParseECPublicKeyFromFile(filename string) (*ecdsa.PublicKey, error) {
+	return ParseECPublicKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseECPublicKeyFromFileAFS parses an ECDSA Public Key or Certificate from a PEM file with a supplied `afero.Fs`.
+func ParseECPublicKeyFromFileAFS(afs afero.Fs, filename string) (*ecdsa.PublicKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate: %w", err)
+	}
+
+	return ParseECPublicKey(data)
+}
+
+// ParseECPublicKey parses an ECDSA Public Key from a byte slice containing either a PEM
+// certificate or a PKIX "PUBLIC KEY" block.
+func ParseECPublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	key, err := ParsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
+	}
+
+	return publicKey, nil
+}
+
+// ParseECPrivateKeyFromFile parses a SEC 1 or PKCS8 ECDSA Private Key from a PEM file.
+func ParseECPrivateKeyFromFile(filename string) (*ecdsa.PrivateKey, error) {
+	return ParseECPrivateKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseECPrivateKeyFromFileAFS parses a SEC 1 or PKCS8 ECDSA Private Key from a PEM file with a supplied `afero.Fs`.
+func ParseECPrivateKeyFromFileAFS(afs afero.Fs, filename string) (*ecdsa.PrivateKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+
+	return ParseECPrivateKey(data)
+}
+
+// ParseECPrivateKey parses a SEC 1 or PKCS8 ECDSA Private Key from a byte slice containing an EC key in PEM format.
+func ParseECPrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return privateKey, nil
+}
+
+// ParseEd25519PublicKeyFromFile parses an Ed25519 Public Key or Certificate from a PEM file.
+func ParseEd25519PublicKeyFromFile(filename string) (ed25519.PublicKey, error) {
+	return ParseEd25519PublicKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseEd25519PublicKeyFromFileAFS parses an Ed25519 Public Key or Certificate from a PEM file
+// with a supplied `afero.Fs`.
+func ParseEd25519PublicKeyFromFileAFS(afs afero.Fs, filename string) (ed25519.PublicKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate: %w", err)
+	}
+
+	return ParseEd25519PublicKey(data)
+}
+
+// ParseEd25519PublicKey parses an Ed25519 Public Key from a byte slice containing either
+// a PEM certificate or a PKIX "PUBLIC KEY" block.
+func ParseEd25519PublicKey(data []byte) (ed25519.PublicKey, error) {
+	key, err := ParsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrExtractPublicKey
+	}
+
+	return publicKey, nil
+}
+
+// ParseEd25519PrivateKeyFromFile parses a PKCS8 Ed25519 Private Key from a PEM file.
+func ParseEd25519PrivateKeyFromFile(filename string) (ed25519.PrivateKey, error) {
+	return ParseEd25519PrivateKeyFromFileAFS(afero.NewOsFs(), filename)
+}
+
+// ParseEd25519PrivateKeyFromFileAFS parses a PKCS8 Ed25519 Private Key from a PEM file with a supplied `afero.Fs`.
+func ParseEd25519PrivateKeyFromFileAFS(afs afero.Fs, filename string) (ed25519.PrivateKey, error) {
+	data, err := afero.ReadFile(afs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key: %w", err)
+	}
+
+	return ParseEd25519PrivateKey(data)
+}
+
+// ParseEd25519PrivateKey parses a PKCS8 Ed25519 Private Key from a byte slice containing a key in PEM format.
+func ParseEd25519PrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrExtractPrivateKey
+	}
+
+	return privateKey, nil
+}
@@ -92,3 +92,201 @@ func TestX509FileOperations_Fail_PrivateKeyFromAFS_FileWrongType(t *testing.T) {
 		t.Error("expected privateKey to be bil, but returned non-nil")
 	}
 }
+
+func TestX509FileOperations_Succeed_ECPublicKeyFromAFS(t *testing.T) {
+	publicKey, err := jwt.ParseECPublicKeyFromFileAFS(createAfs(), "ec-cert.pem")
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if v := reflect.TypeOf(publicKey).String(); v != "*ecdsa.PublicKey" {
+		t.Errorf("expected publicKey to be type(%s), received type(%s)", "*ecdsa.PublicKey", v)
+	}
+}
+
+func TestX509FileOperations_Succeed_ECPrivateKeyFromAFS(t *testing.T) {
+	privateKey, err := jwt.ParseECPrivateKeyFromFileAFS(createAfs(), "ec-key.pem")
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if v := reflect.TypeOf(privateKey).String(); v != "*ecdsa.PrivateKey" {
+		t.Errorf("expected privateKey to be type(%s), received type(%s)", "*ecdsa.PrivateKey", v)
+	}
+}
+
+func TestX509FileOperations_Fail_ECPublicKeyFromAFS_RSACertificate(t *testing.T) {
+	publicKey, err := jwt.ParseECPublicKeyFromFileAFS(createAfs(), "cert.pem")
+	expectErrMatch(t, "jwt.ErrExtractPublicKey", err, jwt.ErrExtractPublicKey)
+	if publicKey != nil {
+		t.Error("expected publicKey to be nil, but returned non-nil")
+	}
+}
+
+func TestX509FileOperations_Fail_ECPrivateKey_NotPEM(t *testing.T) {
+	privateKey, err := jwt.ParseECPrivateKey([]byte("not a pem file"))
+	expectErrMatch(t, "jwt.ErrDecodePEM", err, jwt.ErrDecodePEM)
+	if privateKey != nil {
+		t.Error("expected privateKey to be nil, but returned non-nil")
+	}
+}
+
+func TestX509FileOperations_Succeed_Ed25519PublicKeyFromAFS(t *testing.T) {
+	for _, filename := range []string{"ed-cert.pem", "ed-pub.pem"} {
+		publicKey, err := jwt.ParseEd25519PublicKeyFromFileAFS(createAfs(), filename)
+		if err != nil {
+			t.Errorf("expected error to be nil, returned '%v'", err)
+		}
+		if v := reflect.TypeOf(publicKey).String(); v != "ed25519.PublicKey" {
+			t.Errorf("expected publicKey to be type(%s), received type(%s)", "ed25519.PublicKey", v)
+		}
+	}
+}
+
+func TestX509FileOperations_Succeed_Ed25519PrivateKeyFromAFS(t *testing.T) {
+	privateKey, err := jwt.ParseEd25519PrivateKeyFromFileAFS(createAfs(), "ed-key.pem")
+	if err != nil {
+		t.Errorf("expected error to be nil, returned '%v'", err)
+	}
+	if v := reflect.TypeOf(privateKey).String(); v != "ed25519.PrivateKey" {
+		t.Errorf("expected privateKey to be type(%s), received type(%s)", "ed25519.PrivateKey", v)
+	}
+}
+
+func TestX509FileOperations_Fail_Ed25519PublicKeyFromAFS_RSACertificate(t *testing.T) {
+	publicKey, err := jwt.ParseEd25519PublicKeyFromFileAFS(createAfs(), "cert.pem")
+	expectErrMatch(t, "jwt.ErrExtractPublicKey", err, jwt.ErrExtractPublicKey)
+	if publicKey != nil {
+		t.Error("expected publicKey to be nil, but returned non-nil")
+	}
+}
+
+func TestX509_ParsePublicKey(t *testing.T) {
+	tests := []struct {
+		name, data, keyType string
+	}{
+		{"RSA certificate", rsaPublicKey, "*rsa.PublicKey"},
+		{"RSA PKIX public key", rsaPKIXPublicKey, "*rsa.PublicKey"},
+		{"RSA PKCS1 public key", rsaPKCS1PublicKey, "*rsa.PublicKey"},
+		{"EC certificate", ecPublicKey, "*ecdsa.PublicKey"},
+		{"EC PKIX public key", ecPKIXPublicKey, "*ecdsa.PublicKey"},
+		{"Ed25519 certificate", edPublicCert, "ed25519.PublicKey"},
+		{"Ed25519 PKIX public key", edPublicKey, "ed25519.PublicKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey, err := jwt.ParsePublicKey([]byte(tt.data))
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			if v := reflect.TypeOf(publicKey).String(); v != tt.keyType {
+				t.Errorf("expected publicKey to be type(%s), received type(%s)", tt.keyType, v)
+			}
+		})
+	}
+}
+
+// ecParamsPrivateKey is ecPrivateKey as written by `openssl ecparam -name prime256v1 -genkey`,
+// with the curve parameters before the key.
+const ecParamsPrivateKey = `-----BEGIN EC PARAMETERS-----
+BggqhkjOPQMBBw==
+-----END EC PARAMETERS-----
+` + ecPrivateKey
+
+func TestX509_ParsePrivateKey(t *testing.T) {
+	tests := []struct {
+		name, data, keyType string
+	}{
+		{"RSA PKCS1 private key", rsaPrivateKey, "*rsa.PrivateKey"},
+		{"RSA PKCS8 private key", rsaPKCS8PrivateKey, "*rsa.PrivateKey"},
+		{"EC SEC 1 private key", ecPrivateKey, "*ecdsa.PrivateKey"},
+		{"EC PKCS8 private key", ecPKCS8PrivateKey, "*ecdsa.PrivateKey"},
+		{"EC SEC 1 private key with parameters", ecParamsPrivateKey, "*ecdsa.PrivateKey"},
+		{"Ed25519 PKCS8 private key", edPrivateKey, "ed25519.PrivateKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := jwt.ParsePrivateKey([]byte(tt.data))
+			if err != nil {
+				t.Errorf("expected error to be nil, returned '%v'", err)
+			}
+			if v := reflect.TypeOf(privateKey).String(); v != tt.keyType {
+				t.Errorf("expected privateKey to be type(%s), received type(%s)", tt.keyType, v)
+			}
+		})
+	}
+}
+
+func TestX509_ParseRSAKeys_PKCS8AndPKIX(t *testing.T) {
+	privateKey, err := jwt.ParseRSAPrivateKey([]byte(rsaPKCS8PrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	publicKey, err := jwt.ParseRSAPublicKey([]byte(rsaPKIXPublicKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if !privateKey.PublicKey.Equal(publicKey) {
+		t.Error("expected PKCS8 private key to match PKIX public key")
+	}
+
+	_, err = jwt.ParseRSAPrivateKey([]byte(ecPKCS8PrivateKey))
+	expectErrMatch(t, "jwt.ErrExtractPrivateKey", err, jwt.ErrExtractPrivateKey)
+
+	_, err = jwt.ParseRSAPublicKey([]byte(ecPKIXPublicKey))
+	expectErrMatch(t, "jwt.ErrExtractPublicKey", err, jwt.ErrExtractPublicKey)
+}
+
+func TestX509_ShouldFail_NotPEM(t *testing.T) {
+	if _, err := jwt.ParsePKCS1PublicKey([]byte("not a pem file")); !errors.Is(err, jwt.ErrDecodePEM) {
+		t.Errorf("ParsePKCS1PublicKey: expected err '%v' to match type '%v'", err, jwt.ErrDecodePEM)
+	}
+
+	if _, err := jwt.ParsePKCS1PrivateKey([]byte("not a pem file")); !errors.Is(err, jwt.ErrDecodePEM) {
+		t.Errorf("ParsePKCS1PrivateKey: expected err '%v' to match type '%v'", err, jwt.ErrDecodePEM)
+	}
+
+	if _, err := jwt.ParsePublicKey(nil); !errors.Is(err, jwt.ErrDecodePEM) {
+		t.Errorf("ParsePublicKey: expected err '%v' to match type '%v'", err, jwt.ErrDecodePEM)
+	}
+
+	if _, err := jwt.ParsePrivateKey(nil); !errors.Is(err, jwt.ErrDecodePEM) {
+		t.Errorf("ParsePrivateKey: expected err '%v' to match type '%v'", err, jwt.ErrDecodePEM)
+	}
+}
+
+func TestX509_ShouldFail_UnsupportedPEMBlock(t *testing.T) {
+	_, err := jwt.ParsePublicKey([]byte(rsaPrivateKey))
+	expectErrMatch(t, "jwt.ErrUnsupportedPEMBlock", err, jwt.ErrUnsupportedPEMBlock)
+	if err != nil && !strings.Contains(err.Error(), "RSA PRIVATE KEY") {
+		t.Errorf("expected error to contain '%s', returned '%s'", "RSA PRIVATE KEY", err)
+	}
+
+	_, err = jwt.ParsePrivateKey([]byte(rsaPublicKey))
+	expectErrMatch(t, "jwt.ErrUnsupportedPEMBlock", err, jwt.ErrUnsupportedPEMBlock)
+	if err != nil && !strings.Contains(err.Error(), "CERTIFICATE") {
+		t.Errorf("expected error to contain '%s', returned '%s'", "CERTIFICATE", err)
+	}
+}
+
+func TestX509_ParseECPrivateKey_WithParameters(t *testing.T) {
+	privateKey, err := jwt.ParseECPrivateKey([]byte(ecParamsPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	expected, err := jwt.ParseECPrivateKey([]byte(ecPrivateKey))
+	if err != nil {
+		t.Fatalf("expected error to be nil, returned '%v'", err)
+	}
+
+	if !privateKey.Equal(expected) {
+		t.Error("expected private key with parameters to match private key")
+	}
+
+	parametersOnly := strings.TrimSuffix(ecParamsPrivateKey, ecPrivateKey)
+	_, err = jwt.ParsePrivateKey([]byte(parametersOnly))
+	expectErrMatch(t, "jwt.ErrDecodePEM", err, jwt.ErrDecodePEM)
+}